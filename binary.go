@@ -0,0 +1,93 @@
+package iso8601duration
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	// binaryVersion バイナリ表現のバージョン
+	binaryVersion = 1
+
+	// binaryNegative ビットマップのうち、マイナス期間を表すビット (下位8ビットは各要素の有無)
+	binaryNegative = 1 << 8
+)
+
+// 型チェック
+var (
+	_ encoding.BinaryMarshaler   = Duration{}
+	_ encoding.BinaryUnmarshaler = (*Duration)(nil)
+	_ encoding.BinaryAppender    = Duration{}
+)
+
+// fields は各要素へのポインタを、バイナリ表現のビットマップの順で返す
+func (d *Duration) fields() [8]*uint32 {
+	return [...]*uint32{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes, &d.Seconds, &d.Nanoseconds}
+}
+
+// AppendBinary は encoding.BinaryAppender を実装する
+// バージョン (1バイト)、要素の有無と符号を表すビットマップ (uvarint)、値を持つ要素 (uvarint) の順で出力する
+func (d Duration) AppendBinary(b []byte) ([]byte, error) {
+	fields := d.fields()
+	var bitmap uint64
+	if d.Negative {
+		bitmap |= binaryNegative
+	}
+	for i, f := range fields {
+		if *f != 0 {
+			bitmap |= 1 << i
+		}
+	}
+
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, bitmap)
+	for _, f := range fields {
+		if *f != 0 {
+			b = binary.AppendUvarint(b, uint64(*f))
+		}
+	}
+	return b, nil
+}
+
+// MarshalBinary は encoding.BinaryMarshaler を実装する
+func (d Duration) MarshalBinary() ([]byte, error) {
+	// バージョン + ビットマップ + 要素 (最大5バイト) x 8
+	return d.AppendBinary(make([]byte, 0, 3+5*8))
+}
+
+// UnmarshalBinary は encoding.BinaryUnmarshaler を実装する
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrBadFormat
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: unsupported binary version %d", ErrBadFormat, data[0])
+	}
+	data = data[1:]
+
+	bitmap, n := binary.Uvarint(data)
+	if n <= 0 || bitmap&^(binaryNegative|0xff) != 0 {
+		return ErrBadFormat
+	}
+	data = data[n:]
+
+	r := Duration{Negative: bitmap&binaryNegative != 0}
+	for i, f := range r.fields() {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v > math.MaxUint32 {
+			return ErrBadFormat
+		}
+		*f = uint32(v)
+		data = data[n:]
+	}
+	if len(data) != 0 {
+		return ErrBadFormat
+	}
+	*d = r
+	return nil
+}
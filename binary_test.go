@@ -0,0 +1,92 @@
+package iso8601duration
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestBinaryMarshal(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     []byte
+	}{
+		{duration: "PT0S", want: []byte{1, 0}},
+		{duration: "PT30S", want: []byte{1, 0x40, 30}},
+		{duration: "-PT30S", want: []byte{1, 0xc0, 0x02, 30}},
+		{duration: "P1Y2M", want: []byte{1, 0x03, 1, 2}},
+		{duration: "PT0.5S", want: []byte{1, 0x80, 0x01, 0x80, 0xca, 0xb5, 0xee, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual, err := sut.MarshalBinary()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual)
+
+			appended, err := sut.AppendBinary([]byte{0xff})
+			assert.Nil(t, err)
+			assert.Equal(t, append([]byte{0xff}, tt.want...), appended)
+
+			var decoded Duration
+			assert.Nil(t, decoded.UnmarshalBinary(actual))
+			assert.Equal(t, *sut, decoded)
+		})
+	}
+
+	// フォーマット不正
+	invalid := [][]byte{
+		nil,
+		{2, 0},
+		{1},
+		{1, 0x40},
+		{1, 0x80, 0x04},
+		{1, 0x40, 30, 0},
+		{1, 0x01, 0x80, 0x80, 0x80, 0x80, 0x10},
+	}
+	for _, data := range invalid {
+		var actual Duration
+		assert.ErrorIs(t, actual.UnmarshalBinary(data), ErrBadFormat, "%v", data)
+	}
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		expect := Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32().Draw(t, "years"),
+			Months:      rapid.Uint32().Draw(t, "months"),
+			Weeks:       rapid.Uint32().Draw(t, "weeks"),
+			Days:        rapid.Uint32().Draw(t, "days"),
+			Hours:       rapid.Uint32().Draw(t, "hours"),
+			Minutes:     rapid.Uint32().Draw(t, "minutes"),
+			Seconds:     rapid.Uint32().Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32().Draw(t, "nanoseconds"),
+		}
+
+		data, err := expect.MarshalBinary()
+		assert.Nil(t, err)
+
+		var actual Duration
+		assert.Nil(t, actual.UnmarshalBinary(data))
+		assert.Equal(t, expect, actual)
+	})
+}
+
+func TestGob(t *testing.T) {
+	type payload struct {
+		Name    string
+		Timeout Duration
+	}
+	expect := payload{Name: "job", Timeout: Duration{Negative: true, Days: 1, Nanoseconds: 5}}
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(expect))
+	var actual payload
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(&actual))
+	assert.Equal(t, expect, actual)
+}
@@ -0,0 +1,122 @@
+// Package bsonduration は iso8601duration.Duration を BSON (go.mongodb.org/mongo-driver/v2/bson) で扱うための型を提供する
+package bsonduration
+
+import (
+	"fmt"
+	"math"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// 型チェック
+var (
+	_ bson.ValueMarshaler   = Duration{}
+	_ bson.ValueUnmarshaler = (*Duration)(nil)
+	_ bson.ValueMarshaler   = Document{}
+	_ bson.ValueUnmarshaler = (*Document)(nil)
+)
+
+// Duration は BSON でISO-8601 Duration書式の文字列として扱う Duration
+// デコード時はサブドキュメントも受け付ける
+type Duration struct {
+	iso8601duration.Duration
+}
+
+// Document は BSON で要素ごとのサブドキュメント (ex. {negative: false, years: 1, months: 2, ...}) として扱う Duration
+// 要素ごとに検索や集計が出来るよう、全ての要素を出力する
+// デコード時は文字列も受け付ける
+type Document struct {
+	iso8601duration.Duration
+}
+
+// document はサブドキュメントの構造
+type document struct {
+	Negative    bool  `bson:"negative"`
+	Years       int64 `bson:"years"`
+	Months      int64 `bson:"months"`
+	Weeks       int64 `bson:"weeks"`
+	Days        int64 `bson:"days"`
+	Hours       int64 `bson:"hours"`
+	Minutes     int64 `bson:"minutes"`
+	Seconds     int64 `bson:"seconds"`
+	Nanoseconds int64 `bson:"nanoseconds"`
+}
+
+// MarshalBSONValue は bson.ValueMarshaler を実装する
+func (d Duration) MarshalBSONValue() (byte, []byte, error) {
+	text, err := d.Duration.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+	typ, data, err := bson.MarshalValue(string(text))
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue は bson.ValueUnmarshaler を実装する
+func (d *Duration) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONValue(bson.Type(typ), data, &d.Duration)
+}
+
+// MarshalBSONValue は bson.ValueMarshaler を実装する
+func (d Document) MarshalBSONValue() (byte, []byte, error) {
+	data, err := bson.Marshal(document{
+		Negative:    d.Negative,
+		Years:       int64(d.Years),
+		Months:      int64(d.Months),
+		Weeks:       int64(d.Weeks),
+		Days:        int64(d.Days),
+		Hours:       int64(d.Hours),
+		Minutes:     int64(d.Minutes),
+		Seconds:     int64(d.Seconds),
+		Nanoseconds: int64(d.Nanoseconds),
+	})
+	return byte(bson.TypeEmbeddedDocument), data, err
+}
+
+// UnmarshalBSONValue は bson.ValueUnmarshaler を実装する
+func (d *Document) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONValue(bson.Type(typ), data, &d.Duration)
+}
+
+// unmarshalBSONValue は文字列、またはサブドキュメントをデコードする
+func unmarshalBSONValue(typ bson.Type, data []byte, d *iso8601duration.Duration) error {
+	switch typ {
+	case bson.TypeNull:
+		return nil
+	case bson.TypeString:
+		s, ok := bson.RawValue{Type: typ, Value: data}.StringValueOK()
+		if !ok {
+			return iso8601duration.ErrBadFormat
+		}
+		return d.UnmarshalText([]byte(s))
+	case bson.TypeEmbeddedDocument:
+		var doc document
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		values := [...]int64{doc.Years, doc.Months, doc.Weeks, doc.Days, doc.Hours, doc.Minutes, doc.Seconds, doc.Nanoseconds}
+		for _, v := range values {
+			if v < 0 {
+				return fmt.Errorf("%w: negative component", iso8601duration.ErrBadFormat)
+			}
+			if v > math.MaxUint32 {
+				return iso8601duration.ErrOverflow
+			}
+		}
+		*d = iso8601duration.Duration{
+			Negative:    doc.Negative,
+			Years:       uint32(doc.Years),
+			Months:      uint32(doc.Months),
+			Weeks:       uint32(doc.Weeks),
+			Days:        uint32(doc.Days),
+			Hours:       uint32(doc.Hours),
+			Minutes:     uint32(doc.Minutes),
+			Seconds:     uint32(doc.Seconds),
+			Nanoseconds: uint32(doc.Nanoseconds),
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot decode BSON %s into duration", iso8601duration.ErrBadFormat, typ)
+	}
+}
@@ -0,0 +1,107 @@
+package bsonduration
+
+import (
+	"testing"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"pgregory.net/rapid"
+)
+
+type policy struct {
+	Retention Duration `bson:"retention"`
+	Interval  Document `bson:"interval"`
+}
+
+func TestMarshalBSON(t *testing.T) {
+	sut := policy{
+		Retention: Duration{iso8601duration.Duration{Days: 30}},
+		Interval:  Document{iso8601duration.Duration{Negative: true, Years: 1, Hours: 2}},
+	}
+	data, err := bson.Marshal(sut)
+	assert.Nil(t, err)
+
+	var raw bson.M
+	assert.Nil(t, bson.Unmarshal(data, &raw))
+	assert.Equal(t, bson.M{
+		"retention": "P30D",
+		"interval": bson.D{
+			{Key: "negative", Value: true},
+			{Key: "years", Value: int64(1)},
+			{Key: "months", Value: int64(0)},
+			{Key: "weeks", Value: int64(0)},
+			{Key: "days", Value: int64(0)},
+			{Key: "hours", Value: int64(2)},
+			{Key: "minutes", Value: int64(0)},
+			{Key: "seconds", Value: int64(0)},
+			{Key: "nanoseconds", Value: int64(0)},
+		},
+	}, raw)
+
+	var actual policy
+	assert.Nil(t, bson.Unmarshal(data, &actual))
+	assert.Equal(t, sut, actual)
+}
+
+func TestUnmarshalBSON(t *testing.T) {
+	// 文字列とサブドキュメントの両方を受け付ける
+	data, err := bson.Marshal(bson.D{
+		{Key: "retention", Value: bson.D{{Key: "days", Value: int32(7)}}},
+		{Key: "interval", Value: "-PT1H"},
+	})
+	assert.Nil(t, err)
+	var actual policy
+	assert.Nil(t, bson.Unmarshal(data, &actual))
+	assert.Equal(t, iso8601duration.Duration{Days: 7}, actual.Retention.Duration)
+	assert.Equal(t, iso8601duration.Duration{Negative: true, Hours: 1}, actual.Interval.Duration)
+
+	// null
+	data, err = bson.Marshal(bson.D{{Key: "retention", Value: nil}})
+	assert.Nil(t, err)
+	actual = policy{}
+	assert.Nil(t, bson.Unmarshal(data, &actual))
+	assert.True(t, actual.Retention.IsZero())
+
+	// フォーマット不正
+	invalid := []bson.D{
+		{{Key: "retention", Value: "30 days"}},
+		{{Key: "retention", Value: int32(30)}},
+		{{Key: "retention", Value: bson.D{{Key: "days", Value: int64(-1)}}}},
+		{{Key: "interval", Value: bson.A{"P1D"}}},
+	}
+	for _, doc := range invalid {
+		data, err := bson.Marshal(doc)
+		assert.Nil(t, err)
+		err = bson.Unmarshal(data, &actual)
+		assert.ErrorIs(t, err, iso8601duration.ErrBadFormat)
+	}
+
+	// オーバーフロー
+	data, err = bson.Marshal(bson.D{{Key: "interval", Value: bson.D{{Key: "hours", Value: int64(1 << 32)}}}})
+	assert.Nil(t, err)
+	assert.ErrorIs(t, bson.Unmarshal(data, &actual), iso8601duration.ErrOverflow)
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		d := iso8601duration.Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32().Draw(t, "years"),
+			Months:      rapid.Uint32().Draw(t, "months"),
+			Weeks:       rapid.Uint32().Draw(t, "weeks"),
+			Days:        rapid.Uint32().Draw(t, "days"),
+			Hours:       rapid.Uint32().Draw(t, "hours"),
+			Minutes:     rapid.Uint32().Draw(t, "minutes"),
+			Seconds:     rapid.Uint32().Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32().Draw(t, "nanoseconds"),
+		}
+
+		data, err := bson.Marshal(policy{Interval: Document{d}})
+		assert.Nil(t, err)
+		var actual policy
+		assert.Nil(t, bson.Unmarshal(data, &actual))
+		assert.Equal(t, d, actual.Interval.Duration)
+	})
+}
@@ -0,0 +1,19 @@
+module github.com/gahojin/go-iso8601duration/bsonduration
+
+go 1.24
+
+toolchain go1.25.3
+
+require (
+	github.com/gahojin/go-iso8601duration v0.1.0
+	github.com/stretchr/testify v1.11.1
+	go.mongodb.org/mongo-driver/v2 v2.8.0
+	pgregory.net/rapid v1.2.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/shopspring/decimal v1.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
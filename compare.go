@@ -0,0 +1,71 @@
+package iso8601duration
+
+import (
+	"time"
+)
+
+// Ordering は期間の大小関係を表す
+type Ordering int
+
+const (
+	// OrderingLess 小さい
+	OrderingLess Ordering = iota - 1
+	// OrderingEqual 等しい
+	OrderingEqual
+	// OrderingGreater 大きい
+	OrderingGreater
+	// OrderingIndeterminate 大小関係が決まらない (ex. P1M と P30D)
+	OrderingIndeterminate
+)
+
+// xsdReferenceTimes XML Schema (XSD) で定義されている比較用の基準日時
+// 月の日数や閏年の違いが全て現れるよう選ばれている
+var xsdReferenceTimes = [...]time.Time{
+	time.Date(1696, 9, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1697, 2, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 3, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 7, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func (o Ordering) String() string {
+	switch o {
+	case OrderingLess:
+		return "<"
+	case OrderingEqual:
+		return "="
+	case OrderingGreater:
+		return ">"
+	default:
+		return "<>"
+	}
+}
+
+// Compare は XML Schema (XSD) の半順序に従い、期間の大小関係を返す
+// 4つの基準日時 (1696-09-01, 1697-02-01, 1903-03-01, 1903-07-01) にそれぞれの期間を加算し、
+// 全ての基準日時で同じ大小関係となる場合のみ、その関係を返す
+// 大小関係が決まらない場合、 OrderingIndeterminate と false を返す
+func Compare(a, b Duration) (Ordering, bool) {
+	result := OrderingIndeterminate
+	for i, ref := range xsdReferenceTimes {
+		o := Ordering(a.nanosecondsAt(ref).Cmp(b.nanosecondsAt(ref)))
+		if i == 0 {
+			result = o
+		} else if result != o {
+			return OrderingIndeterminate, false
+		}
+	}
+	return result, true
+}
+
+// CompareAt は基準日時 ref に期間を加算した日時を比較し、 a < b なら -1, a == b なら 0, a > b なら +1 を返す
+func CompareAt(ref time.Time, a, b Duration) int {
+	return a.nanosecondsAt(ref).Cmp(b.nanosecondsAt(ref))
+}
+
+// CompareFuncAt は基準日時 ref で比較する関数を返す
+// slices.SortFunc などにそのまま渡すことが出来る
+func CompareFuncAt(ref time.Time) func(a, b Duration) int {
+	return func(a, b Duration) int {
+		return CompareAt(ref, a, b)
+	}
+}
@@ -0,0 +1,143 @@
+package iso8601duration
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want Ordering
+	}{
+		{a: "P1Y", b: "P364D", want: OrderingGreater},
+		{a: "P1Y", b: "P365D", want: OrderingIndeterminate},
+		{a: "P1Y", b: "P366D", want: OrderingIndeterminate},
+		{a: "P1Y", b: "P367D", want: OrderingLess},
+		{a: "P1M", b: "P27D", want: OrderingGreater},
+		{a: "P1M", b: "P28D", want: OrderingIndeterminate},
+		{a: "P1M", b: "P30D", want: OrderingIndeterminate},
+		{a: "P1M", b: "P32D", want: OrderingLess},
+		{a: "P5M", b: "P149D", want: OrderingGreater},
+		{a: "P5M", b: "P150D", want: OrderingIndeterminate},
+		{a: "P5M", b: "P154D", want: OrderingLess},
+		{a: "P1Y", b: "P12M", want: OrderingEqual},
+		{a: "P1D", b: "PT24H", want: OrderingEqual},
+		{a: "P1W", b: "P7D", want: OrderingEqual},
+		{a: "PT1H", b: "PT59M", want: OrderingGreater},
+		{a: "-P1D", b: "PT1S", want: OrderingLess},
+		{a: "-P1M", b: "-P32D", want: OrderingGreater},
+		// time.Duration の範囲 (約292年) を超える時刻部
+		{a: "PT3000000H", b: "PT1H", want: OrderingGreater},
+		{a: "-PT3000000H", b: "-PT1H", want: OrderingLess},
+		{a: "PT4294967295H", b: "P1Y", want: OrderingGreater},
+		{a: "PT8760H", b: "P1Y", want: OrderingIndeterminate},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.a, tt.b), func(t *testing.T) {
+			a, err := ParseString(tt.a)
+			assert.Nil(t, err)
+			b, err := ParseString(tt.b)
+			assert.Nil(t, err)
+
+			actual, ok := Compare(*a, *b)
+			assert.Equal(t, tt.want, actual)
+			assert.Equal(t, tt.want != OrderingIndeterminate, ok)
+		})
+	}
+
+	// プロパティテスト (反対称性)
+	rapid.Check(t, func(t *rapid.T) {
+		a := Duration{
+			Negative: rapid.Bool().Draw(t, "negative1"),
+			Months:   rapid.Uint32Max(1000).Draw(t, "months1"),
+			Days:     rapid.Uint32Max(30000).Draw(t, "days1"),
+		}
+		b := Duration{
+			Negative: rapid.Bool().Draw(t, "negative2"),
+			Months:   rapid.Uint32Max(1000).Draw(t, "months2"),
+			Days:     rapid.Uint32Max(30000).Draw(t, "days2"),
+		}
+
+		o1, ok1 := Compare(a, b)
+		o2, ok2 := Compare(b, a)
+		assert.Equal(t, ok1, ok2)
+		if ok1 {
+			assert.Equal(t, -o1, o2)
+		} else {
+			assert.Equal(t, OrderingIndeterminate, o2)
+		}
+	})
+
+	// プロパティテスト (時刻部のみの場合、合計の時間の大小と一致する)
+	rapid.Check(t, func(t *rapid.T) {
+		a := Duration{
+			Hours:   rapid.Uint32().Draw(t, "hours1"),
+			Minutes: rapid.Uint32Max(59).Draw(t, "minutes1"),
+		}
+		b := Duration{
+			Hours:   rapid.Uint32().Draw(t, "hours2"),
+			Minutes: rapid.Uint32Max(59).Draw(t, "minutes2"),
+		}
+
+		want := Ordering(cmp.Compare(uint64(a.Hours)*60+uint64(a.Minutes), uint64(b.Hours)*60+uint64(b.Minutes)))
+		actual, ok := Compare(a, b)
+		assert.True(t, ok)
+		assert.Equal(t, want, actual)
+	})
+}
+
+func TestCompareAt(t *testing.T) {
+	p1m := Duration{Months: 1}
+	p30d := Duration{Days: 30}
+
+	// 2月を基準とする場合、1ヶ月は30日より短い
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, -1, CompareAt(ref, p1m, p30d))
+	assert.Equal(t, 1, CompareAt(ref, p30d, p1m))
+
+	// 9月を基準とする場合、1ヶ月は30日と等しい
+	ref = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 0, CompareAt(ref, p1m, p30d))
+
+	// 10月を基準とする場合、1ヶ月は30日より長い
+	ref = time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 1, CompareAt(ref, p1m, p30d))
+
+	// マイナス
+	assert.Equal(t, -1, CompareAt(ref, p1m.Negate(), p30d))
+	assert.Equal(t, 0, CompareAt(ref, p1m.Negate(), p30d.Negate()))
+	assert.Equal(t, -1, CompareAt(ref, p1m.Negate(), Duration{Negative: true, Days: 29}))
+
+	// time.Duration の範囲 (約292年) を超える時刻部
+	assert.Equal(t, 1, CompareAt(ref, Duration{Hours: 3000000}, Duration{Hours: 1}))
+	assert.Equal(t, -1, CompareAt(ref, Duration{Negative: true, Hours: 3000000}, Duration{Negative: true, Hours: 1}))
+	assert.Equal(t, 0, CompareAt(ref, Duration{Hours: 3000000}, Duration{Days: 125000}))
+}
+
+func TestCompareFuncAt(t *testing.T) {
+	durations := []Duration{
+		{Months: 1},
+		{Days: 29},
+		{Negative: true, Days: 1},
+		{Hours: 1},
+		{Weeks: 4},
+	}
+
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	slices.SortFunc(durations, CompareFuncAt(ref))
+	assert.Equal(t, []Duration{
+		{Negative: true, Days: 1},
+		{Hours: 1},
+		{Months: 1},
+		{Weeks: 4},
+		{Days: 29},
+	}, durations)
+}
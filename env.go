@@ -0,0 +1,149 @@
+package iso8601duration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+)
+
+var (
+	// ErrInvalidEnvTarget デコード先が構造体へのポインタではない
+	ErrInvalidEnvTarget = errors.New("env target must be a non-nil pointer to a struct")
+	// ErrOutOfRange 期間が min / max タグの範囲外
+	ErrOutOfRange = errors.New("duration out of range")
+)
+
+var durationType = reflect.TypeFor[Duration]()
+
+// EnvDecoder は環境変数から構造体の Duration フィールドに値を設定する
+//
+// フィールドには以下のタグを指定する
+//   - env: 環境変数名
+//   - default: 環境変数が未設定、または空の場合の値
+//     どちらも無い場合、 Duration フィールドは変更せず、 *Duration フィールドは nil のままとする
+//   - min: 最小値 (この値を含む)
+//   - max: 最大値 (この値を含む)
+//
+// min / max は年月を含む期間も比較出来るよう、基準日時に期間を加算した日時で比較する (CompareAt)
+type EnvDecoder struct {
+	// LookupEnv 環境変数を取得する関数 (nil の場合は os.LookupEnv)
+	LookupEnv func(key string) (string, bool)
+	// Reference min / max を比較する基準日時 (ゼロ値の場合は time.Now)
+	Reference time.Time
+}
+
+// DecodeEnv は os.LookupEnv と現在日時を使用し、 v (構造体へのポインタ) の Duration フィールドに値を設定する
+func DecodeEnv(v any) error {
+	return EnvDecoder{}.Decode(v)
+}
+
+// Decode は v (構造体へのポインタ) の env タグを持つ Duration 、 *Duration フィールドに値を設定する
+// 埋め込み、またはネストした構造体のフィールドも対象とし、 Duration 以外のフィールドは env タグを持っていても無視する
+func (e EnvDecoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidEnvTarget
+	}
+	if e.LookupEnv == nil {
+		e.LookupEnv = os.LookupEnv
+	}
+	if e.Reference.IsZero() {
+		e.Reference = time.Now()
+	}
+	return e.decodeStruct(rv.Elem())
+}
+
+func (e EnvDecoder) decodeStruct(rv reflect.Value) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		key, ok := field.Tag.Lookup("env")
+		if !ok {
+			// ネストした構造体
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				if err := e.decodeStruct(fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		var d *Duration
+		// 未設定の *Duration フィールド (nil) は、値がある場合のみ設定する
+		var unset bool
+		switch {
+		case field.Type == durationType:
+			d = fv.Addr().Interface().(*Duration)
+		case field.Type.Kind() == reflect.Pointer && field.Type.Elem() == durationType:
+			unset = fv.IsNil()
+			if unset {
+				d = new(Duration)
+			} else {
+				d = fv.Interface().(*Duration)
+			}
+		default:
+			// Duration 以外のフィールドは、他の環境変数ライブラリで設定するため無視する
+			continue
+		}
+
+		ok, err := e.decodeField(field, key, d)
+		if err == nil && (ok || !unset) {
+			err = e.checkRange(field, d)
+		}
+		if err != nil {
+			return fmt.Errorf("%s (%s): %w", field.Name, key, err)
+		}
+		if ok && unset {
+			fv.Set(reflect.ValueOf(d))
+		}
+	}
+	return nil
+}
+
+// decodeField は環境変数、または default タグの値を d に設定する
+// どちらも無い場合は d を変更せず、 false を返す
+func (e EnvDecoder) decodeField(field reflect.StructField, key string, d *Duration) (bool, error) {
+	if value, ok := e.LookupEnv(key); ok && value != "" {
+		if err := d.UnmarshalText([]byte(value)); err != nil {
+			return false, fmt.Errorf("%w: %q", err, value)
+		}
+		return true, nil
+	}
+	if value, ok := field.Tag.Lookup("default"); ok {
+		if err := d.UnmarshalText([]byte(value)); err != nil {
+			return false, fmt.Errorf("default tag: %w: %q", err, value)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// checkRange は d が min / max タグの範囲内か判定する
+func (e EnvDecoder) checkRange(field reflect.StructField, d *Duration) error {
+	if value, ok := field.Tag.Lookup("min"); ok {
+		var minimum Duration
+		if err := minimum.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("min tag: %w: %q", err, value)
+		}
+		if CompareAt(e.Reference, *d, minimum) < 0 {
+			return fmt.Errorf("%w: %s is less than %s", ErrOutOfRange, d, value)
+		}
+	}
+	if value, ok := field.Tag.Lookup("max"); ok {
+		var maximum Duration
+		if err := maximum.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("max tag: %w: %q", err, value)
+		}
+		if CompareAt(e.Reference, *d, maximum) > 0 {
+			return fmt.Errorf("%w: %s is greater than %s", ErrOutOfRange, d, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package iso8601duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvDecoder(t *testing.T) {
+	type nested struct {
+		Interval Duration `env:"INTERVAL" default:"PT5M"`
+	}
+	type config struct {
+		Retention  Duration  `env:"RETENTION" default:"P7D" min:"PT1H" max:"P1Y"`
+		Timeout    *Duration `env:"TIMEOUT"`
+		Deadline   *Duration `env:"DEADLINE" min:"PT1S"`
+		Grace      *Duration `env:"GRACE" default:"PT10S"`
+		Nested     nested
+		Ignored    Duration
+		Name       string   `env:"NAME"`
+		unexported Duration `env:"UNEXPORTED"`
+	}
+
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	decode := func(env map[string]string, v any) error {
+		return EnvDecoder{
+			LookupEnv: func(key string) (string, bool) {
+				value, ok := env[key]
+				return value, ok
+			},
+			Reference: ref,
+		}.Decode(v)
+	}
+
+	// 既定値
+	var c config
+	assert.Nil(t, decode(nil, &c))
+	assert.Equal(t, Duration{Days: 7}, c.Retention)
+	assert.Nil(t, c.Timeout)
+	// 未設定の場合、 min / max は判定しない
+	assert.Nil(t, c.Deadline)
+	assert.Equal(t, &Duration{Seconds: 10}, c.Grace)
+	assert.Equal(t, Duration{Minutes: 5}, c.Nested.Interval)
+
+	// 環境変数
+	c = config{}
+	assert.Nil(t, decode(map[string]string{
+		"RETENTION":  "P1M",
+		"TIMEOUT":    "PT30S",
+		"INTERVAL":   "",
+		"NAME":       "app",
+		"UNEXPORTED": "P1D",
+	}, &c))
+	assert.Equal(t, Duration{Months: 1}, c.Retention)
+	assert.Equal(t, &Duration{Seconds: 30}, c.Timeout)
+	assert.Equal(t, Duration{Minutes: 5}, c.Nested.Interval)
+	assert.Equal(t, Duration{}, c.unexported)
+	// Duration 以外のフィールドは無視する
+	assert.Equal(t, "", c.Name)
+
+	// 範囲の境界
+	c = config{}
+	assert.Nil(t, decode(map[string]string{"RETENTION": "PT1H"}, &c))
+	assert.Nil(t, decode(map[string]string{"RETENTION": "P365D"}, &c))
+	assert.Nil(t, decode(map[string]string{"RETENTION": "P12M"}, &c))
+
+	// 範囲外
+	err := decode(map[string]string{"RETENTION": "PT59M"}, &c)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.EqualError(t, err, "Retention (RETENTION): duration out of range: PT59M is less than PT1H")
+	err = decode(map[string]string{"RETENTION": "P1Y1D"}, &c)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.EqualError(t, err, "Retention (RETENTION): duration out of range: P1Y1D is greater than P1Y")
+	err = decode(map[string]string{"DEADLINE": "PT0S"}, &c)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.EqualError(t, err, "Deadline (DEADLINE): duration out of range: PT0S is less than PT1S")
+	// time.Duration の範囲を超える時刻部
+	err = decode(map[string]string{"RETENTION": "PT3000000H"}, &c)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestEnvDecoderReference(t *testing.T) {
+	var c struct {
+		Retention Duration `env:"RETENTION" max:"P30D"`
+	}
+	env := map[string]string{"RETENTION": "P1M"}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	// 2月を基準とする場合、1ヶ月は30日より短い
+	assert.Nil(t, EnvDecoder{LookupEnv: lookup, Reference: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)}.Decode(&c))
+	// 1月を基準とする場合、1ヶ月は30日より長い
+	assert.ErrorIs(t, EnvDecoder{LookupEnv: lookup, Reference: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}.Decode(&c), ErrOutOfRange)
+}
+
+func TestEnvDecoderError(t *testing.T) {
+	lookup := func(key string) (string, bool) {
+		return "30d", true
+	}
+	decoder := EnvDecoder{LookupEnv: lookup}
+
+	// フォーマット不正
+	var c1 struct {
+		Retention Duration `env:"RETENTION"`
+	}
+	err := decoder.Decode(&c1)
+	assert.ErrorIs(t, err, ErrBadFormat)
+	assert.EqualError(t, err, `Retention (RETENTION): bad format string: "30d"`)
+
+	// タグの値が不正
+	var c2 struct {
+		Retention Duration `env:"RETENTION" min:"1h"`
+	}
+	err = EnvDecoder{LookupEnv: func(string) (string, bool) { return "", false }}.Decode(&c2)
+	assert.ErrorIs(t, err, ErrBadFormat)
+	assert.EqualError(t, err, `Retention (RETENTION): min tag: bad format string: "1h"`)
+
+	// 構造体へのポインタ以外
+	assert.ErrorIs(t, decoder.Decode(c1), ErrInvalidEnvTarget)
+	assert.ErrorIs(t, decoder.Decode((*struct{})(nil)), ErrInvalidEnvTarget)
+	assert.ErrorIs(t, decoder.Decode(new(int)), ErrInvalidEnvTarget)
+}
+
+func TestDecodeEnv(t *testing.T) {
+	t.Setenv("ISO8601DURATION_TEST_RETENTION", "P30D")
+	var c struct {
+		Retention Duration `env:"ISO8601DURATION_TEST_RETENTION" min:"P1D"`
+	}
+	assert.Nil(t, DecodeEnv(&c))
+	assert.Equal(t, Duration{Days: 30}, c.Retention)
+}
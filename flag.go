@@ -0,0 +1,66 @@
+package iso8601duration
+
+import (
+	"flag"
+)
+
+// flagUsageSuffix コマンドラインのヘルプに表示する書式の説明
+// flag.PrintDefaults はバッククォートで囲んだ語を値の名前として表示する (ex. -retention duration)
+const flagUsageSuffix = " (ISO-8601 `duration`, e.g. P30D, PT1H30M, -P1Y)"
+
+// 型チェック
+var (
+	_ flag.Value  = (*FlagValue)(nil)
+	_ flag.Getter = (*FlagValue)(nil)
+)
+
+// FlagValue は flag.Value を実装し、コマンドライン引数をISO-8601 Duration書式としてパースする
+// Type メソッドを持つため、 spf13/pflag の Value としても使用出来る
+type FlagValue struct {
+	p *Duration
+}
+
+// NewFlagValue は p に値を設定する FlagValue を返す
+func NewFlagValue(p *Duration) *FlagValue {
+	return &FlagValue{p: p}
+}
+
+// String は flag.Value を実装する
+func (v *FlagValue) String() string {
+	if v == nil || v.p == nil {
+		// flag パッケージはゼロ値の判定のため、ゼロ値の FlagValue の String を呼び出す
+		return Duration{}.String()
+	}
+	return v.p.String()
+}
+
+// Set は flag.Value を実装する
+func (v *FlagValue) Set(s string) error {
+	return v.p.UnmarshalText([]byte(s))
+}
+
+// Get は flag.Getter を実装する
+func (v *FlagValue) Get() any {
+	return *v.p
+}
+
+// Type は spf13/pflag の Value を実装する
+// pflag の time.Duration のフラグ ("duration") として GetDuration で取得されないよう、別の型名とする
+func (v *FlagValue) Type() string {
+	return "isoDuration"
+}
+
+// DurationVar は fs に Duration のフラグを定義し、 p に値を設定する
+// usage には書式の説明が追加される
+func DurationVar(fs *flag.FlagSet, p *Duration, name string, value Duration, usage string) {
+	*p = value
+	fs.Var(NewFlagValue(p), name, usage+flagUsageSuffix)
+}
+
+// DurationFlag は fs に Duration のフラグを定義し、値を設定するポインタを返す
+// usage には書式の説明が追加される
+func DurationFlag(fs *flag.FlagSet, name string, value Duration, usage string) *Duration {
+	p := new(Duration)
+	DurationVar(fs, p, name, value, usage)
+	return p
+}
@@ -0,0 +1,59 @@
+package iso8601duration
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var retention Duration
+	DurationVar(fs, &retention, "retention", Duration{Days: 7}, "retention period")
+	timeout := DurationFlag(fs, "timeout", Duration{}, "request timeout")
+
+	// 既定値
+	assert.Equal(t, Duration{Days: 7}, retention)
+	assert.Equal(t, Duration{}, *timeout)
+
+	assert.Nil(t, fs.Parse([]string{"--retention=P30D", "-timeout", "PT1M30S"}))
+	assert.Equal(t, Duration{Days: 30}, retention)
+	assert.Equal(t, Duration{Minutes: 1, Seconds: 30}, *timeout)
+	assert.Equal(t, Duration{Days: 30}, fs.Lookup("retention").Value.(flag.Getter).Get())
+
+	// フォーマット不正
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	err := fs.Parse([]string{"--retention=30d"})
+	assert.EqualError(t, err, `invalid value "30d" for flag -retention: bad format string`)
+}
+
+func TestDurationFlagUsage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	DurationFlag(fs, "retention", Duration{Days: 7}, "retention period")
+	DurationFlag(fs, "timeout", Duration{}, "request timeout")
+	fs.PrintDefaults()
+
+	assert.Equal(t, "  -retention duration\n"+
+		"    \tretention period (ISO-8601 duration, e.g. P30D, PT1H30M, -P1Y) (default P7D)\n"+
+		"  -timeout duration\n"+
+		"    \trequest timeout (ISO-8601 duration, e.g. P30D, PT1H30M, -P1Y)\n", buf.String())
+}
+
+func TestFlagValuePflag(t *testing.T) {
+	// spf13/pflag の Value と同じメソッドを持つ
+	var v interface {
+		String() string
+		Set(string) error
+		Type() string
+	} = NewFlagValue(new(Duration))
+
+	assert.Equal(t, "PT0S", v.String())
+	assert.Nil(t, v.Set("-P1Y"))
+	assert.Equal(t, "-P1Y", v.String())
+	assert.Equal(t, "isoDuration", v.Type())
+}
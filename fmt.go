@@ -0,0 +1,83 @@
+package iso8601duration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 型チェック
+var (
+	_ fmt.Formatter = Duration{}
+)
+
+// plainDuration はメソッドを持たない Duration (フィールドの出力用)
+type plainDuration Duration
+
+// Format は fmt.Formatter を実装する
+//
+//   - %s, %v: ISO-8601 Duration書式 (ex. P1DT1.5S)
+//   - %q: ダブルクォートで囲んだISO-8601 Duration書式
+//   - %+v: フィールドの一覧 (ex. {Negative:false Years:0 ...})
+//   - %#v: Go の構文 (ex. iso8601duration.Duration{Negative:false, ...})
+//   - %h: 英語の読みやすい書式 (ex. 1 day, 1.5 seconds)
+//
+// 精度を指定した場合、秒の小数部をその桁数に切り捨て、または0埋めする (ex. %.3s は PT1.500S)
+// 幅と - フラグを指定した場合、空白で埋める
+func (d Duration) Format(f fmt.State, verb rune) {
+	precision, ok := f.Precision()
+	if !ok {
+		precision = -1
+	}
+	var opts FormatOptions
+	if precision >= 0 {
+		opts = FormatOptions{FractionDigits: precision, FixedFraction: true}
+	}
+
+	var b []byte
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			s := fmt.Sprintf("%#v", plainDuration(d))
+			_, _ = fmt.Fprint(f, "iso8601duration.Duration"+s[strings.IndexByte(s, '{'):])
+			return
+		}
+		if f.Flag('+') {
+			_, _ = fmt.Fprintf(f, "%+v", plainDuration(d))
+			return
+		}
+		b = opts.appendFormat(make([]byte, 0, maxStringLength), d)
+	case 's':
+		b = opts.appendFormat(make([]byte, 0, maxStringLength), d)
+	case 'q':
+		b = strconv.AppendQuote(nil, string(opts.appendFormat(make([]byte, 0, maxStringLength), d)))
+	case 'h':
+		humanize := HumanizeOptions{List: ListStyleComma}
+		if precision >= 0 {
+			humanize.FractionDigits, humanize.FixedFraction = precision, true
+		}
+		b = []byte(humanize.Humanize(d))
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(iso8601duration.Duration=%s)", verb, d.String())
+		return
+	}
+	writePadded(f, b)
+}
+
+// writePadded は幅の指定に従って空白で埋め、 b を出力する
+func writePadded(f fmt.State, b []byte) {
+	width, ok := f.Width()
+	if !ok {
+		_, _ = f.Write(b)
+		return
+	}
+	padding := []byte(strings.Repeat(" ", max(width-utf8.RuneCount(b), 0)))
+	if f.Flag('-') {
+		_, _ = f.Write(b)
+		_, _ = f.Write(padding)
+	} else {
+		_, _ = f.Write(padding)
+		_, _ = f.Write(b)
+	}
+}
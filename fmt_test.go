@@ -0,0 +1,51 @@
+package iso8601duration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	d := Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Seconds: 1, Nanoseconds: 500000000}
+	tests := []struct {
+		format string
+		d      Duration
+		want   string
+	}{
+		{format: "%s", d: d, want: "P1Y2M3DT4H1.5S"},
+		{format: "%v", d: d, want: "P1Y2M3DT4H1.5S"},
+		{format: "%q", d: d, want: `"P1Y2M3DT4H1.5S"`},
+		{format: "%.3s", d: Duration{Seconds: 1, Nanoseconds: 500000000}, want: "PT1.500S"},
+		{format: "%.3v", d: Duration{Seconds: 1, Nanoseconds: 123456789}, want: "PT1.123S"},
+		{format: "%.0s", d: Duration{Seconds: 1, Nanoseconds: 999999999}, want: "PT1S"},
+		{format: "%.0s", d: Duration{Hours: 1, Nanoseconds: 400000000}, want: "PT1H"},
+		{format: "%.12s", d: Duration{Nanoseconds: 1}, want: "PT0.000000001000S"},
+		{format: "%.3s", d: Duration{Hours: 1}, want: "PT1H"},
+		{format: "%.3s", d: Duration{Negative: true, Minutes: 1, Seconds: 2}, want: "-PT1M2.000S"},
+		{format: "%.3s", d: Duration{}, want: "PT0.000S"},
+		{format: "%s", d: Duration{Negative: true}, want: "PT0S"},
+		{format: "%8s|", d: Duration{Days: 1}, want: "     P1D|"},
+		{format: "%-8s|", d: Duration{Days: 1}, want: "P1D     |"},
+		{format: "%+v", d: Duration{Negative: true, Days: 1}, want: "{Negative:true Years:0 Months:0 Weeks:0 Days:1 Hours:0 Minutes:0 Seconds:0 Nanoseconds:0}"},
+		{format: "%#v", d: Duration{Days: 1}, want: "iso8601duration.Duration{Negative:false, Years:0x0, Months:0x0, Weeks:0x0, Days:0x1, Hours:0x0, Minutes:0x0, Seconds:0x0, Nanoseconds:0x0}"},
+		{format: "%d", d: Duration{Days: 1}, want: "%!d(iso8601duration.Duration=P1D)"},
+		{format: "%h", d: d, want: "1 year, 2 months, 3 days, 4 hours, 1.5 seconds"},
+		{format: "%h", d: Duration{Negative: true, Weeks: 2, Minutes: 1, Seconds: 1}, want: "minus 2 weeks, 1 minute, 1 second"},
+		{format: "%h", d: Duration{}, want: "0 seconds"},
+		{format: "%h", d: Duration{Nanoseconds: 2500000000}, want: "2.5 seconds"},
+		{format: "%.2h", d: Duration{Hours: 1, Seconds: 3}, want: "1 hour, 3.00 seconds"},
+		{format: "%.0h", d: Duration{Seconds: 1, Nanoseconds: 500000000}, want: "1 second"},
+		{format: "%.2h", d: Duration{Days: 1}, want: "1 day"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.d))
+		})
+	}
+
+	// ポインタと値で同じ書式となる
+	assert.Equal(t, "P1Y2M3DT4H1.5S\n", fmt.Sprintln(d))
+	assert.Equal(t, fmt.Sprintf("%.3s", d), fmt.Sprintf("%.3s", &d))
+}
@@ -0,0 +1,286 @@
+package iso8601duration
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ZeroStyle はゼロ値の期間の表現を表す
+type ZeroStyle int
+
+const (
+	// ZeroStylePT0S PT0S
+	ZeroStylePT0S ZeroStyle = iota
+	// ZeroStyleP0D P0D
+	ZeroStyleP0D
+)
+
+// RoundingMode は小数部の桁数を制限する際の丸め方を表す
+// 期間の絶対値に対して丸める
+type RoundingMode int
+
+const (
+	// RoundingTruncate 切り捨て
+	RoundingTruncate RoundingMode = iota
+	// RoundingHalfUp 四捨五入
+	RoundingHalfUp
+	// RoundingHalfEven 偶数丸め (銀行丸め)
+	RoundingHalfEven
+	// RoundingUp 切り上げ
+	RoundingUp
+)
+
+// defaultMaxFractionDigits 小数部の既定の最大桁数
+const defaultMaxFractionDigits = 9
+
+// FormatOptions はISO-8601 Duration書式の出力方法を表す
+// ゼロ値は String と同じ書式となる
+type FormatOptions struct {
+	// FractionDigits 秒の小数部の最小桁数 (FixedFraction の場合は桁数)
+	FractionDigits int
+	// FixedFraction 秒の小数部を FractionDigits 桁に切り捨て、または0埋めする
+	// false の場合は末尾の0を除き、 FractionDigits 桁に満たない場合のみ0埋めする
+	FixedFraction bool
+	// DecimalComma 小数点に , を使用する (ISO-8601 で推奨される表記)
+	DecimalComma bool
+	// AlwaysTime 時刻部がない場合も T 以降を出力する (ex. P1DT0S)
+	AlwaysTime bool
+	// ZeroComponents 値が0の要素も出力する (ex. P0Y0M1DT0H0M0S)
+	// 週は値が0でない場合のみ出力する
+	ZeroComponents bool
+	// Zero ゼロ値の期間の表現 (ZeroComponents の場合は無視する)
+	Zero ZeroStyle
+	// MaxFractionDigits 小数部の最大桁数 (0の場合は9桁、 FixedFraction の場合は無視する)
+	MaxFractionDigits int
+	// Rounding 小数部の桁数を制限する際の丸め方
+	Rounding RoundingMode
+	// FractionUnit 指定した単位未満の要素を、その単位の小数として出力する (ex. PT1.5H, P2.5D)
+	// UnitMinute, UnitHour, UnitDay のみ有効で、1日は24時間として換算する
+	// 小数を持つ要素は最後の要素となるため、 AlwaysTime と時刻部の ZeroComponents は無視する
+	FractionUnit Unit
+}
+
+// Format は opts に従い、期間をISO-8601 Duration書式の文字列に変換する
+func Format(d Duration, opts FormatOptions) string {
+	return string(opts.appendFormat(make([]byte, 0, maxStringLength), d))
+}
+
+// AppendFormat は opts に従い、期間をISO-8601 Duration書式の文字列として b に追加する
+func AppendFormat(b []byte, d Duration, opts FormatOptions) []byte {
+	return opts.appendFormat(b, d)
+}
+
+// appendFormat は期間をISO-8601 Duration書式の文字列として b に追加する
+func (o FormatOptions) appendFormat(b []byte, d Duration) []byte {
+	switch o.FractionUnit {
+	case UnitMinute, UnitHour, UnitDay:
+		if !d.IsZero() {
+			return o.appendFractionUnit(b, d)
+		}
+	}
+
+	// ナノ秒のうち、秒単位の桁は、秒に加算する
+	// 丸めにより0となる場合は秒を出力しないよう、先に丸める
+	seconds := uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second)
+	nanoseconds := d.Nanoseconds % uint32(time.Second)
+	if nanoseconds != 0 {
+		seconds, nanoseconds = o.roundNanoseconds(seconds, nanoseconds)
+	}
+	hasTime := d.Hours != 0 || d.Minutes != 0 || seconds != 0 || nanoseconds != 0
+	zero := !hasTime && !d.HasDatePart()
+
+	if d.Negative && !zero {
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+	b = o.appendComponent(b, d.Years, 'Y')
+	b = o.appendComponent(b, d.Months, 'M')
+	b = appendUnit(b, d.Weeks, 'W')
+	b = o.appendComponent(b, d.Days, 'D')
+	if zero && !o.ZeroComponents && o.Zero == ZeroStyleP0D {
+		b = append(b, "0D"...)
+	}
+
+	if !hasTime && !o.ZeroComponents && !o.AlwaysTime && (!zero || o.Zero == ZeroStyleP0D) {
+		return b
+	}
+	b = append(b, 'T')
+	b = o.appendComponent(b, d.Hours, 'H')
+	b = o.appendComponent(b, d.Minutes, 'M')
+	if seconds == 0 && nanoseconds == 0 && hasTime && !o.ZeroComponents {
+		return b
+	}
+	b = strconv.AppendUint(b, seconds, 10)
+	b = o.appendFraction(b, nanoseconds)
+	return append(b, 'S')
+}
+
+// appendComponent は値が0でない場合 (ZeroComponents の場合は常に) 、値と単位を b に追加する
+func (o FormatOptions) appendComponent(b []byte, v uint32, designator byte) []byte {
+	if v == 0 && o.ZeroComponents {
+		return append(b, '0', designator)
+	}
+	return appendUnit(b, v, designator)
+}
+
+// appendFraction は小数点と、ナノ秒を小数部として b に追加する
+// 小数部の桁数が0の場合は何も追加しない
+func (o FormatOptions) appendFraction(b []byte, nanoseconds uint32) []byte {
+	digits := o.FractionDigits
+	if !o.FixedFraction && nanoseconds != 0 {
+		// 末尾の0を除いた桁数 (roundNanoseconds で最大桁数に丸め済み)
+		n := 9
+		for v := nanoseconds; v%10 == 0; v /= 10 {
+			n--
+		}
+		digits = max(digits, n)
+	}
+	if digits <= 0 {
+		return b
+	}
+	if o.DecimalComma {
+		b = append(b, ',')
+	} else {
+		b = append(b, '.')
+	}
+	return appendFixedFraction(b, nanoseconds, digits)
+}
+
+// maxFractionDigits は小数部の最大桁数を返す
+func (o FormatOptions) maxFractionDigits() int {
+	switch {
+	case o.FixedFraction:
+		return max(o.FractionDigits, 0)
+	case o.MaxFractionDigits > 0:
+		return o.MaxFractionDigits
+	default:
+		return defaultMaxFractionDigits
+	}
+}
+
+// roundNanoseconds はナノ秒を小数部の最大桁数に丸める
+// 切り上げにより1秒となる場合は、秒に繰り上げる
+func (o FormatOptions) roundNanoseconds(seconds uint64, nanoseconds uint32) (uint64, uint32) {
+	digits := o.maxFractionDigits()
+	if digits >= 9 {
+		return seconds, nanoseconds
+	}
+	unit := uint32(1)
+	for range 9 - digits {
+		unit *= 10
+	}
+	q, r := nanoseconds/unit, nanoseconds%unit
+	switch o.Rounding {
+	case RoundingHalfUp:
+		if r*2 >= unit {
+			q++
+		}
+	case RoundingHalfEven:
+		if r*2 > unit || (r*2 == unit && q%2 == 1) {
+			q++
+		}
+	case RoundingUp:
+		if r != 0 {
+			q++
+		}
+	}
+	nanoseconds = q * unit
+	if nanoseconds >= uint32(time.Second) {
+		return seconds + 1, nanoseconds - uint32(time.Second)
+	}
+	return seconds, nanoseconds
+}
+
+// round は v を小数部の最大桁数に丸める
+func (o FormatOptions) round(v decimal.Decimal) decimal.Decimal {
+	places := int32(o.maxFractionDigits())
+	switch o.Rounding {
+	case RoundingHalfUp:
+		return v.Round(places)
+	case RoundingHalfEven:
+		return v.RoundBank(places)
+	case RoundingUp:
+		return v.RoundUp(places)
+	default:
+		return v.Truncate(places)
+	}
+}
+
+// appendFractionUnit は FractionUnit 未満の要素を FractionUnit の小数として、期間を b に追加する
+func (o FormatOptions) appendFractionUnit(b []byte, d Duration) []byte {
+	// FractionUnit 以下の要素を合計する
+	ns := decimal.NewFromUint64(uint64(d.Seconds)).Mul(nanosecondsPerSeconds).Add(decimal.NewFromUint64(uint64(d.Nanoseconds)))
+	rest := d
+	rest.Seconds, rest.Nanoseconds = 0, 0
+	switch o.FractionUnit {
+	case UnitDay:
+		ns = ns.Add(decimal.NewFromUint64(uint64(d.Days)).Mul(nanosecondsPerDay))
+		rest.Days = 0
+		fallthrough
+	case UnitHour:
+		ns = ns.Add(decimal.NewFromUint64(uint64(d.Hours)).Mul(nanosecondsPerHour))
+		rest.Hours = 0
+		fallthrough
+	case UnitMinute:
+		ns = ns.Add(decimal.NewFromUint64(uint64(d.Minutes)).Mul(nanosecondsPerMinute))
+		rest.Minutes = 0
+	}
+	value := o.round(ns.Div(o.FractionUnit.nanoseconds()))
+
+	// 丸めにより0となった要素は、他の要素がない場合のみ出力する
+	emit := !value.IsZero() || o.ZeroComponents || rest.IsZero()
+	if d.Negative && (!value.IsZero() || !rest.IsZero()) {
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+	b = o.appendComponent(b, rest.Years, 'Y')
+	b = o.appendComponent(b, rest.Months, 'M')
+	b = appendUnit(b, rest.Weeks, 'W')
+	if o.FractionUnit == UnitDay {
+		if emit {
+			b = o.appendDecimal(b, value)
+			b = append(b, 'D')
+		}
+		return b
+	}
+	b = o.appendComponent(b, rest.Days, 'D')
+	if !emit && rest.Hours == 0 {
+		return b
+	}
+	b = append(b, 'T')
+	if o.FractionUnit == UnitHour {
+		b = o.appendDecimal(b, value)
+		return append(b, 'H')
+	}
+	b = o.appendComponent(b, rest.Hours, 'H')
+	if emit {
+		b = o.appendDecimal(b, value)
+		b = append(b, 'M')
+	}
+	return b
+}
+
+// appendDecimal は丸め済みの値を、小数部の桁数の指定に従って b に追加する
+func (o FormatOptions) appendDecimal(b []byte, v decimal.Decimal) []byte {
+	s := v.StringFixed(int32(o.maxFractionDigits()))
+	integer, fraction, _ := strings.Cut(s, ".")
+	if !o.FixedFraction {
+		fraction = strings.TrimRight(fraction, "0")
+		if len(fraction) < o.FractionDigits {
+			fraction += strings.Repeat("0", o.FractionDigits-len(fraction))
+		}
+	}
+	b = append(b, integer...)
+	if fraction == "" {
+		return b
+	}
+	if o.DecimalComma {
+		b = append(b, ',')
+	} else {
+		b = append(b, '.')
+	}
+	return append(b, fraction...)
+}
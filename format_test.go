@@ -0,0 +1,157 @@
+package iso8601duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestFormatOptions(t *testing.T) {
+	d := Duration{Years: 1, Days: 2, Seconds: 3, Nanoseconds: 450000000}
+	tests := []struct {
+		name string
+		d    Duration
+		opts FormatOptions
+		want string
+	}{
+		{name: "default", d: d, opts: FormatOptions{}, want: "P1Y2DT3.45S"},
+		{name: "fixed", d: d, opts: FormatOptions{FractionDigits: 3, FixedFraction: true}, want: "P1Y2DT3.450S"},
+		{name: "fixed truncate", d: d, opts: FormatOptions{FractionDigits: 1, FixedFraction: true}, want: "P1Y2DT3.4S"},
+		{name: "fixed 0", d: d, opts: FormatOptions{FixedFraction: true}, want: "P1Y2DT3S"},
+		{name: "minimum", d: d, opts: FormatOptions{FractionDigits: 3}, want: "P1Y2DT3.450S"},
+		{name: "minimum exceeded", d: Duration{Nanoseconds: 123456}, opts: FormatOptions{FractionDigits: 3}, want: "PT0.000123456S"},
+		{name: "minimum integer", d: Duration{Seconds: 1}, opts: FormatOptions{FractionDigits: 1}, want: "PT1.0S"},
+		{name: "comma", d: d, opts: FormatOptions{DecimalComma: true}, want: "P1Y2DT3,45S"},
+		{name: "always time", d: Duration{Days: 1}, opts: FormatOptions{AlwaysTime: true}, want: "P1DT0S"},
+		{name: "always time with time", d: Duration{Days: 1, Minutes: 1}, opts: FormatOptions{AlwaysTime: true}, want: "P1DT1M"},
+		{name: "zero components", d: Duration{Negative: true, Days: 1}, opts: FormatOptions{ZeroComponents: true}, want: "-P0Y0M1DT0H0M0S"},
+		{name: "zero components with week", d: Duration{Weeks: 1}, opts: FormatOptions{ZeroComponents: true}, want: "P0Y0M1W0DT0H0M0S"},
+		{name: "zero components fraction", d: d, opts: FormatOptions{ZeroComponents: true, FractionDigits: 3, FixedFraction: true, DecimalComma: true}, want: "P1Y0M2DT0H0M3,450S"},
+		{name: "zero", d: Duration{Negative: true}, opts: FormatOptions{}, want: "PT0S"},
+		{name: "zero P0D", d: Duration{}, opts: FormatOptions{Zero: ZeroStyleP0D}, want: "P0D"},
+		{name: "zero P0D always time", d: Duration{}, opts: FormatOptions{Zero: ZeroStyleP0D, AlwaysTime: true}, want: "P0DT0S"},
+		{name: "zero components zero", d: Duration{}, opts: FormatOptions{ZeroComponents: true, Zero: ZeroStyleP0D}, want: "P0Y0M0DT0H0M0S"},
+		{name: "zero fixed", d: Duration{}, opts: FormatOptions{FractionDigits: 2, FixedFraction: true}, want: "PT0.00S"},
+		{name: "time without seconds", d: Duration{Hours: 1}, opts: FormatOptions{FractionDigits: 2, FixedFraction: true}, want: "PT1H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.d, tt.opts))
+			assert.Equal(t, "x"+tt.want, string(AppendFormat([]byte("x"), tt.d, tt.opts)))
+		})
+	}
+
+	// プロパティテスト (小数部を切り捨てない場合、パース結果が一致する)
+	rapid.Check(t, func(t *rapid.T) {
+		expect := Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32().Draw(t, "years"),
+			Months:      rapid.Uint32().Draw(t, "months"),
+			Weeks:       rapid.Uint32().Draw(t, "weeks"),
+			Days:        rapid.Uint32().Draw(t, "days"),
+			Hours:       rapid.Uint32().Draw(t, "hours"),
+			Minutes:     rapid.Uint32().Draw(t, "minutes"),
+			Seconds:     rapid.Uint32Max(1000000000).Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32Max(999999999).Draw(t, "nanoseconds"),
+		}
+		opts := FormatOptions{
+			FractionDigits: rapid.IntRange(0, 12).Draw(t, "fractionDigits"),
+			DecimalComma:   rapid.Bool().Draw(t, "decimalComma"),
+			AlwaysTime:     rapid.Bool().Draw(t, "alwaysTime"),
+			ZeroComponents: rapid.Bool().Draw(t, "zeroComponents"),
+			Zero:           ZeroStyle(rapid.IntRange(0, 1).Draw(t, "zero")),
+		}
+
+		actual, err := ParseString(Format(expect, opts))
+		assert.Nil(t, err)
+		if expect.IsZero() {
+			expect.Negative = false
+		}
+		expect.Seconds += uint32(time.Duration(expect.Nanoseconds) / time.Second)
+		expect.Nanoseconds = uint32(time.Duration(expect.Nanoseconds) % time.Second)
+		assert.Equal(t, expect, *actual)
+	})
+}
+
+func TestFormatRounding(t *testing.T) {
+	d := Duration{Seconds: 1, Nanoseconds: 234500000}
+	tests := []struct {
+		name string
+		d    Duration
+		opts FormatOptions
+		want string
+	}{
+		{name: "truncate", d: d, opts: FormatOptions{MaxFractionDigits: 3}, want: "PT1.234S"},
+		{name: "half up", d: d, opts: FormatOptions{MaxFractionDigits: 3, Rounding: RoundingHalfUp}, want: "PT1.235S"},
+		{name: "half even", d: d, opts: FormatOptions{MaxFractionDigits: 3, Rounding: RoundingHalfEven}, want: "PT1.234S"},
+		{name: "half even odd", d: Duration{Seconds: 1, Nanoseconds: 233500000}, opts: FormatOptions{MaxFractionDigits: 3, Rounding: RoundingHalfEven}, want: "PT1.234S"},
+		{name: "up", d: Duration{Seconds: 1, Nanoseconds: 1}, opts: FormatOptions{MaxFractionDigits: 3, Rounding: RoundingUp}, want: "PT1.001S"},
+		{name: "carry", d: Duration{Seconds: 1, Nanoseconds: 999600000}, opts: FormatOptions{MaxFractionDigits: 3, Rounding: RoundingHalfUp}, want: "PT2S"},
+		{name: "fixed half up", d: d, opts: FormatOptions{FractionDigits: 1, FixedFraction: true, Rounding: RoundingHalfUp}, want: "PT1.2S"},
+		{name: "fixed 0 half up", d: Duration{Nanoseconds: 500000000}, opts: FormatOptions{FixedFraction: true, Rounding: RoundingHalfUp}, want: "PT1S"},
+		// 丸めにより0となる場合は、秒を出力しない
+		{name: "rounded to zero", d: Duration{Hours: 1, Nanoseconds: 1}, opts: FormatOptions{MaxFractionDigits: 2}, want: "PT1H"},
+		{name: "rounded to zero date", d: Duration{Days: 1, Nanoseconds: 1}, opts: FormatOptions{MaxFractionDigits: 2}, want: "P1D"},
+		{name: "rounded to zero all", d: Duration{Negative: true, Nanoseconds: 1}, opts: FormatOptions{MaxFractionDigits: 2}, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.d, tt.opts))
+		})
+	}
+}
+
+func TestFormatFractionUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		opts FormatOptions
+		want string
+	}{
+		{name: "hour", d: Duration{Hours: 1, Minutes: 30}, opts: FormatOptions{FractionUnit: UnitHour}, want: "PT1.5H"},
+		{name: "hour with date", d: Duration{Negative: true, Years: 1, Days: 2, Minutes: 15}, opts: FormatOptions{FractionUnit: UnitHour}, want: "-P1Y2DT0.25H"},
+		{name: "hour without time", d: Duration{Days: 2}, opts: FormatOptions{FractionUnit: UnitHour}, want: "P2D"},
+		{name: "hour comma", d: Duration{Hours: 1, Minutes: 30}, opts: FormatOptions{FractionUnit: UnitHour, DecimalComma: true}, want: "PT1,5H"},
+		{name: "hour minimum digits", d: Duration{Hours: 2}, opts: FormatOptions{FractionUnit: UnitHour, FractionDigits: 1}, want: "PT2.0H"},
+		{name: "hour fixed", d: Duration{Hours: 1, Minutes: 20}, opts: FormatOptions{FractionUnit: UnitHour, FractionDigits: 3, FixedFraction: true}, want: "PT1.333H"},
+		{name: "hour max digits", d: Duration{Hours: 1, Minutes: 40}, opts: FormatOptions{FractionUnit: UnitHour, MaxFractionDigits: 2}, want: "PT1.66H"},
+		{name: "hour max digits half up", d: Duration{Hours: 1, Minutes: 40}, opts: FormatOptions{FractionUnit: UnitHour, MaxFractionDigits: 2, Rounding: RoundingHalfUp}, want: "PT1.67H"},
+		{name: "hour default digits", d: Duration{Minutes: 20}, opts: FormatOptions{FractionUnit: UnitHour}, want: "PT0.333333333H"},
+		{name: "day", d: Duration{Days: 2, Hours: 12}, opts: FormatOptions{FractionUnit: UnitDay}, want: "P2.5D"},
+		{name: "day with week", d: Duration{Weeks: 1, Hours: 36}, opts: FormatOptions{FractionUnit: UnitDay}, want: "P1W1.5D"},
+		{name: "day zero components", d: Duration{Hours: 6}, opts: FormatOptions{FractionUnit: UnitDay, ZeroComponents: true}, want: "P0Y0M0.25D"},
+		{name: "minute", d: Duration{Hours: 1, Minutes: 2, Seconds: 30}, opts: FormatOptions{FractionUnit: UnitMinute}, want: "PT1H2.5M"},
+		{name: "minute without hour", d: Duration{Seconds: 90}, opts: FormatOptions{FractionUnit: UnitMinute}, want: "PT1.5M"},
+		{name: "minute only hour", d: Duration{Hours: 1}, opts: FormatOptions{FractionUnit: UnitMinute}, want: "PT1H"},
+		{name: "rounded to zero", d: Duration{Negative: true, Nanoseconds: 1}, opts: FormatOptions{FractionUnit: UnitHour}, want: "PT0H"},
+		{name: "rounded to zero with date", d: Duration{Days: 1, Nanoseconds: 1}, opts: FormatOptions{FractionUnit: UnitHour}, want: "P1D"},
+		{name: "zero", d: Duration{}, opts: FormatOptions{FractionUnit: UnitHour}, want: "PT0S"},
+		{name: "unsupported unit", d: Duration{Months: 1, Days: 15}, opts: FormatOptions{FractionUnit: UnitMonth}, want: "P1M15D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.d, tt.opts))
+		})
+	}
+
+	// プロパティテスト (割り切れる場合、パース結果の合計が一致する)
+	// 1日 = 86400秒 = 2^7 * 3^3 * 5^2 秒のため、27秒の倍数であれば小数が有限桁となる
+	rapid.Check(t, func(t *rapid.T) {
+		expect := Duration{
+			Negative: rapid.Bool().Draw(t, "negative"),
+			Days:     rapid.Uint32Max(1000).Draw(t, "days"),
+			Hours:    rapid.Uint32Max(1000).Draw(t, "hours") * 3,
+			Minutes:  rapid.Uint32Max(1000).Draw(t, "minutes") * 9,
+			Seconds:  rapid.Uint32Max(1000).Draw(t, "seconds") * 27,
+		}
+		unit := rapid.SampledFrom([]Unit{UnitMinute, UnitHour, UnitDay}).Draw(t, "unit")
+
+		actual, err := ParseString(Format(expect, FormatOptions{FractionUnit: unit}))
+		assert.Nil(t, err)
+		expectSeconds, _ := expect.TotalSeconds()
+		actualSeconds, _ := actual.TotalSeconds()
+		assert.True(t, expectSeconds.Equal(actualSeconds), "%s != %s", expectSeconds, actualSeconds)
+	})
+}
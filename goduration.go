@@ -0,0 +1,210 @@
+package iso8601duration
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 型チェック
+var (
+	_ encoding.TextUnmarshaler = (*LenientDuration)(nil)
+	_ json.Unmarshaler         = (*LenientDuration)(nil)
+)
+
+// goUnits Go の time.ParseDuration 書式の単位と、1単位あたりのナノ秒数
+var goUnits = map[string]decimal.Decimal{
+	"ns": one,
+	"us": nanosecondsPerMicrosecond,
+	"µs": nanosecondsPerMicrosecond, // U+00B5
+	"μs": nanosecondsPerMicrosecond, // U+03BC
+	"ms": decimal.NewFromInt(int64(time.Millisecond)),
+	"s":  nanosecondsPerSeconds,
+	"m":  nanosecondsPerMinute,
+	"h":  nanosecondsPerHour,
+}
+
+// GoParseOptions は Go の time.ParseDuration 書式のパース方法を表す
+type GoParseOptions struct {
+	// AllowDays 日の単位 d を受け付ける (ex. 7d12h)
+	// 整数部は Days 、小数部は24時間として時刻部に換算する
+	AllowDays bool
+}
+
+// LenientDuration はテキストとして、ISO-8601 Duration書式に加えて、 Go の time.ParseDuration 書式 (日の単位 d を含む) を受け付ける Duration
+// 設定ファイルの移行時など、両方の書式が混在する場合に使用する
+// 出力はISO-8601 Duration書式とする
+type LenientDuration struct {
+	Duration
+}
+
+// ParseGo は Go の time.ParseDuration 書式 (ex. 1h30m, 1.5s, 300ms, -2h45m) をパースし、 Duration を返す
+// time.Duration の範囲に制限されず、時刻部は FromTimeDuration と同様に時分秒に分割する
+func ParseGo(s string) (*Duration, error) {
+	return GoParseOptions{}.Parse(s)
+}
+
+// Parse は Go の time.ParseDuration 書式をパースし、 Duration を返す
+func (o GoParseOptions) Parse(s string) (*Duration, error) {
+	orig := s
+	negative := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return &Duration{}, nil
+	}
+	if s == "" {
+		return nil, fmt.Errorf("%w: %q", ErrBadFormat, orig)
+	}
+
+	ns, days := decimal.Zero, decimal.Zero
+	for s != "" {
+		// 数値 (ex. 1, 1.5, .5)
+		i := strings.IndexFunc(s, func(r rune) bool { return !isDigit(r) && r != '.' })
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrBadFormat, orig)
+		}
+		number := s[:i]
+		if strings.Count(number, ".") > 1 || number == "." {
+			return nil, fmt.Errorf("%w: %q", ErrBadFormat, orig)
+		}
+		// 1.s のように小数部を省略出来る
+		v, err := decimal.NewFromString(strings.TrimSuffix(number, "."))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrBadFormat, orig)
+		}
+		s = s[i:]
+
+		// 単位
+		i = strings.IndexFunc(s, func(r rune) bool { return isDigit(r) || r == '.' })
+		if i < 0 {
+			i = len(s)
+		}
+		unit := s[:i]
+		s = s[i:]
+		if unit == "d" && o.AllowDays {
+			days = days.Add(v)
+			continue
+		}
+		scale, ok := goUnits[unit]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown unit %q in %q", ErrBadFormat, unit, orig)
+		}
+		ns = ns.Add(v.Mul(scale))
+	}
+
+	var d Duration
+	wholeDays := days.Truncate(0)
+	if wholeDays.GreaterThan(decimal.NewFromInt(math.MaxUint32)) {
+		return nil, fmt.Errorf("%w: %q", ErrOverflow, orig)
+	}
+	d.Days = uint32(wholeDays.IntPart())
+	ns = ns.Add(days.Sub(wholeDays).Mul(nanosecondsPerDay)).Truncate(0)
+
+	hours := ns.Div(nanosecondsPerHour).Truncate(0)
+	if hours.GreaterThan(decimal.NewFromInt(math.MaxUint32)) {
+		return nil, fmt.Errorf("%w: %q", ErrOverflow, orig)
+	}
+	d.Hours = uint32(hours.IntPart())
+	rest := uint64(ns.Sub(hours.Mul(nanosecondsPerHour)).IntPart())
+	d.Minutes = uint32(rest / uint64(time.Minute))
+	d.Seconds = uint32(rest / uint64(time.Second) % 60)
+	d.Nanoseconds = uint32(rest % uint64(time.Second))
+	d.Negative = negative && !d.IsZero()
+	return &d, nil
+}
+
+// FormatGo は期間を Go の time.Duration.String と同じ書式 (ex. 1h30m0s, 1.5s, 300ms) に変換する
+// time.Duration の範囲に制限されず、1日は24時間として換算する
+// 年月を含む場合は ErrCalendarComponent を返す
+func FormatGo(d Duration) (string, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return "", ErrCalendarComponent
+	}
+	seconds := (((uint64(d.Weeks)*7+uint64(d.Days))*24+uint64(d.Hours))*60+uint64(d.Minutes))*60 + uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second)
+	nanoseconds := uint64(d.Nanoseconds) % uint64(time.Second)
+	if seconds == 0 && nanoseconds == 0 {
+		return "0s", nil
+	}
+
+	b := make([]byte, 0, 32)
+	if d.Negative {
+		b = append(b, '-')
+	}
+	if seconds == 0 {
+		// 1秒未満は、ミリ秒・マイクロ秒・ナノ秒で表す
+		switch {
+		case nanoseconds < uint64(time.Microsecond):
+			b = strconv.AppendUint(b, nanoseconds, 10)
+			b = append(b, "ns"...)
+		case nanoseconds < uint64(time.Millisecond):
+			b = appendGoUnit(b, nanoseconds, uint64(time.Microsecond))
+			b = append(b, "µs"...)
+		default:
+			b = appendGoUnit(b, nanoseconds, uint64(time.Millisecond))
+			b = append(b, "ms"...)
+		}
+		return string(b), nil
+	}
+
+	if hours := seconds / 3600; hours != 0 {
+		b = strconv.AppendUint(b, hours, 10)
+		b = append(b, 'h')
+	}
+	if seconds >= 60 {
+		b = strconv.AppendUint(b, seconds/60%60, 10)
+		b = append(b, 'm')
+	}
+	b = strconv.AppendUint(b, seconds%60, 10)
+	b = FormatOptions{}.appendFraction(b, uint32(nanoseconds))
+	b = append(b, 's')
+	return string(b), nil
+}
+
+// appendGoUnit は v を scale で割った値を、末尾の0を除いた小数として b に追加する
+func appendGoUnit(b []byte, v, scale uint64) []byte {
+	b = strconv.AppendUint(b, v/scale, 10)
+	frac := v % scale
+	if frac == 0 {
+		return b
+	}
+	b = append(b, '.')
+	for scale /= 10; frac != 0; scale /= 10 {
+		b = append(b, byte('0'+frac/scale))
+		frac %= scale
+	}
+	return b
+}
+
+// UnmarshalText は encoding.TextUnmarshaler を実装する
+// ISO-8601 Duration書式としてパース出来ない場合、 Go の time.ParseDuration 書式 (日の単位 d を含む) としてパースする
+func (d *LenientDuration) UnmarshalText(data []byte) error {
+	err := d.Duration.UnmarshalText(data)
+	if err == nil {
+		return nil
+	}
+	r, goErr := GoParseOptions{AllowDays: true}.Parse(string(data))
+	if goErr != nil {
+		return fmt.Errorf("%w: %q", err, data)
+	}
+	d.Duration = *r
+	return nil
+}
+
+// UnmarshalJSON は json.Unmarshaler を実装する
+// 文字列は UnmarshalText と同様にパースし、それ以外は Duration と同様にオブジェクト・秒数を受け付ける
+func (d *LenientDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return d.Duration.UnmarshalJSON(data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
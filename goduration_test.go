@@ -0,0 +1,145 @@
+package iso8601duration
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestParseGo(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Duration
+	}{
+		{s: "1h30m", want: Duration{Hours: 1, Minutes: 30}},
+		{s: "1.5s", want: Duration{Seconds: 1, Nanoseconds: 500000000}},
+		{s: "300ms", want: Duration{Nanoseconds: 300000000}},
+		{s: "-2h45m", want: Duration{Negative: true, Hours: 2, Minutes: 45}},
+		{s: "+90m", want: Duration{Hours: 1, Minutes: 30}},
+		{s: "1.5h", want: Duration{Hours: 1, Minutes: 30}},
+		{s: ".5m", want: Duration{Seconds: 30}},
+		{s: "1.s", want: Duration{Seconds: 1}},
+		{s: "1us2µs3μs4ns", want: Duration{Nanoseconds: 6004}},
+		{s: "0", want: Duration{}},
+		{s: "-0", want: Duration{}},
+		{s: "0s", want: Duration{}},
+		{s: "1000000h", want: Duration{Hours: 1000000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			actual, err := ParseGo(tt.s)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+
+	// 日の単位
+	actual, err := GoParseOptions{AllowDays: true}.Parse("7d12h")
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Days: 7, Hours: 12}, *actual)
+	actual, err = GoParseOptions{AllowDays: true}.Parse("-1.5d")
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Negative: true, Days: 1, Hours: 12}, *actual)
+	_, err = ParseGo("7d")
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	// フォーマット不正
+	for _, s := range []string{"", "-", "1", "h", "1.2.3s", "1x", "1h-30m", "P1D", ".s"} {
+		_, err := ParseGo(s)
+		assert.ErrorIs(t, err, ErrBadFormat, s)
+	}
+
+	// オーバーフロー
+	_, err = ParseGo("4294967296h")
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = GoParseOptions{AllowDays: true}.Parse("4294967296d")
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	// プロパティテスト (time.ParseDuration と一致する)
+	rapid.Check(t, func(t *rapid.T) {
+		td := time.Duration(rapid.Int64Range(math.MinInt64+1, math.MaxInt64).Draw(t, "duration"))
+		actual, err := ParseGo(td.String())
+		assert.Nil(t, err)
+		assert.Equal(t, FromTimeDuration(td), *actual)
+	})
+}
+
+func TestFormatGo(t *testing.T) {
+	tests := []struct {
+		d    Duration
+		want string
+	}{
+		{d: Duration{}, want: "0s"},
+		{d: Duration{Negative: true}, want: "0s"},
+		{d: Duration{Hours: 1, Minutes: 30}, want: "1h30m0s"},
+		{d: Duration{Days: 1}, want: "24h0m0s"},
+		{d: Duration{Weeks: 1, Seconds: 1, Nanoseconds: 500000000}, want: "168h0m1.5s"},
+		{d: Duration{Minutes: 90}, want: "1h30m0s"},
+		{d: Duration{Nanoseconds: 300000000}, want: "300ms"},
+		{d: Duration{Nanoseconds: 1500}, want: "1.5µs"},
+		{d: Duration{Negative: true, Nanoseconds: 1}, want: "-1ns"},
+		{d: Duration{Hours: math.MaxUint32}, want: "4294967295h0m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			actual, err := FormatGo(tt.d)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	_, err := FormatGo(Duration{Months: 1})
+	assert.ErrorIs(t, err, ErrCalendarComponent)
+
+	// プロパティテスト (time.Duration.String と一致する)
+	rapid.Check(t, func(t *rapid.T) {
+		td := time.Duration(rapid.Int64Range(math.MinInt64+1, math.MaxInt64).Draw(t, "duration"))
+		actual, err := FormatGo(FromTimeDuration(td))
+		assert.Nil(t, err)
+		assert.Equal(t, td.String(), actual)
+	})
+}
+
+func TestLenientDuration(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Duration
+	}{
+		{s: "P30D", want: Duration{Days: 30}},
+		{s: "1h30m", want: Duration{Hours: 1, Minutes: 30}},
+		{s: "7d", want: Duration{Days: 7}},
+		{s: "-300ms", want: Duration{Negative: true, Nanoseconds: 300000000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			var d LenientDuration
+			assert.Nil(t, d.UnmarshalText([]byte(tt.s)))
+			assert.Equal(t, tt.want, d.Duration)
+		})
+	}
+
+	var d LenientDuration
+	err := d.UnmarshalText([]byte("30 days"))
+	assert.ErrorIs(t, err, ErrBadFormat)
+	assert.EqualError(t, err, `bad format string: "30 days"`)
+
+	// JSON
+	var v struct {
+		A LenientDuration `json:"a"`
+		B LenientDuration `json:"b"`
+		C LenientDuration `json:"c"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":"PT1H","b":"90m","c":90}`), &v))
+	assert.Equal(t, Duration{Hours: 1}, v.A.Duration)
+	assert.Equal(t, Duration{Hours: 1, Minutes: 30}, v.B.Duration)
+	assert.Equal(t, Duration{Minutes: 1, Seconds: 30}, v.C.Duration)
+
+	// 出力はISO-8601 Duration書式
+	b, err := json.Marshal(v.B)
+	assert.Nil(t, err)
+	assert.Equal(t, `"PT1H30M"`, string(b))
+}
@@ -0,0 +1,183 @@
+package iso8601duration
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HumanizeStyle は読みやすい書式の単位の表記を表す
+type HumanizeStyle int
+
+const (
+	// HumanizeLong 1 year, 2 months and 3 days
+	HumanizeLong HumanizeStyle = iota
+	// HumanizeShort 1y 2mo 3d
+	HumanizeShort
+	// HumanizeNarrow 1y2mo3d
+	HumanizeNarrow
+)
+
+// ListStyle は要素の連結方法を表す
+type ListStyle int
+
+const (
+	// ListStyleDefault 言語、書式ごとの既定 (英語の場合、 HumanizeLong は ListStyleAnd, HumanizeShort は ListStyleSpace, HumanizeNarrow は ListStyleNone)
+	ListStyleDefault ListStyle = iota
+	// ListStyleAnd 1 year, 2 months and 3 days
+	ListStyleAnd
+	// ListStyleComma 1 year, 2 months, 3 days
+	ListStyleComma
+	// ListStyleSpace 1 year 2 months 3 days
+	// 言語によっては、空白を含まない (ex. 1年2个月)
+	ListStyleSpace
+	// ListStyleNone 1y2mo3d
+	ListStyleNone
+)
+
+// HumanizeOptions は読みやすい書式の出力方法を表す
+type HumanizeOptions struct {
+	// Style 単位の表記
+	Style HumanizeStyle
+	// MaxUnits 出力する要素の最大数 (0の場合は全て)
+	// 値が0の要素は数えず、上位の要素から出力する
+	MaxUnits int
+	// Rounding MaxUnits により省略した端数、秒の小数部の丸め方
+	// 年月と週日時分秒をまたぐ端数は GregorianAverage で換算する
+	Rounding RoundingMode
+	// List 要素の連結方法
+	List ListStyle
+	// Relative 相対的な表現とする (ex. in 3 days, 3 days ago)
+	// Negative の場合は過去、それ以外は未来とする
+	Relative bool
+	// FractionDigits 秒の小数部の最小桁数 (FixedFraction の場合は桁数)
+	FractionDigits int
+	// FixedFraction 秒の小数部を FractionDigits 桁に丸める
+	FixedFraction bool
+}
+
+// humanUnits 読みやすい書式で出力する単位 (上位から順に並べる)
+var humanUnits = [...]Unit{UnitYear, UnitMonth, UnitWeek, UnitDay, UnitHour, UnitMinute, UnitSecond}
+
+// humanPart は読みやすい書式で出力する要素
+type humanPart struct {
+	unit Unit
+	// value 値 (秒の場合は小数部を含む、 ex. 1.5)
+	value string
+}
+
+// Humanize は期間を英語の読みやすい書式に変換する
+func Humanize(d Duration, style HumanizeStyle) string {
+	return HumanizeOptions{Style: style}.Humanize(d)
+}
+
+// Humanize は期間を英語の読みやすい書式に変換する
+// 言語タグ en に登録されたデータ (RegisterLocale) を使用する
+func (o HumanizeOptions) Humanize(d Duration) string {
+	return englishLocale().Humanize(d, o)
+}
+
+// parts は期間を、出力する要素に分割する (符号は含まない)
+// ゼロ値の場合は、0秒とする
+func (o HumanizeOptions) parts(d Duration) []humanPart {
+	// ナノ秒のうち、秒単位の桁は、秒に加算する
+	values := [len(humanUnits)]uint64{
+		uint64(d.Years), uint64(d.Months), uint64(d.Weeks), uint64(d.Days), uint64(d.Hours), uint64(d.Minutes),
+		uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second),
+	}
+	nanoseconds := d.Nanoseconds % uint32(time.Second)
+	if o.MaxUnits > 0 {
+		nanoseconds = o.truncate(&values, nanoseconds)
+	}
+
+	formatOptions := FormatOptions{FractionDigits: o.FractionDigits, FixedFraction: o.FixedFraction, Rounding: o.Rounding}
+	var parts []humanPart
+	for i, unit := range humanUnits {
+		if unit == UnitSecond {
+			if values[i] == 0 && nanoseconds == 0 && len(parts) > 0 {
+				break
+			}
+			seconds, ns := values[i], nanoseconds
+			if ns != 0 {
+				seconds, ns = formatOptions.roundNanoseconds(seconds, ns)
+			}
+			b := strconv.AppendUint(nil, seconds, 10)
+			parts = append(parts, humanPart{unit: unit, value: string(formatOptions.appendFraction(b, ns))})
+			break
+		}
+		if values[i] != 0 {
+			parts = append(parts, humanPart{unit: unit, value: strconv.FormatUint(values[i], 10)})
+		}
+	}
+	return parts
+}
+
+// truncate は上位から MaxUnits 個の要素を残し、残りの要素を端数として丸める
+// 丸め後のナノ秒を返す
+func (o HumanizeOptions) truncate(values *[len(humanUnits)]uint64, nanoseconds uint32) uint32 {
+	var nonzero []int
+	for i, v := range values {
+		if v != 0 || (humanUnits[i] == UnitSecond && nanoseconds != 0) {
+			nonzero = append(nonzero, i)
+		}
+	}
+	if len(nonzero) <= o.MaxUnits {
+		return nanoseconds
+	}
+
+	last := nonzero[o.MaxUnits-1]
+	// 端数を、最後の要素の単位で表す
+	restMonths := decimal.Zero
+	restNs := decimal.NewFromUint64(uint64(nanoseconds))
+	for i := last + 1; i < len(values); i++ {
+		v := decimal.NewFromUint64(values[i])
+		if humanUnits[i].isCalendar() {
+			restMonths = restMonths.Add(v.Mul(humanUnits[i].months()))
+		} else {
+			restNs = restNs.Add(v.Mul(humanUnits[i].nanoseconds()))
+		}
+		values[i] = 0
+	}
+	monthNs := GregorianAverage.DaysPerMonth.Mul(nanosecondsPerDay)
+	var r decimal.Decimal
+	if unit := humanUnits[last]; unit.isCalendar() {
+		r = restMonths.Add(restNs.Div(monthNs)).Div(unit.months())
+	} else {
+		r = restNs.Div(unit.nanoseconds())
+	}
+
+	half := decimal.NewFromFloat(0.5)
+	var up bool
+	switch o.Rounding {
+	case RoundingHalfUp:
+		up = r.GreaterThanOrEqual(half)
+	case RoundingHalfEven:
+		up = r.GreaterThan(half) || (r.Equal(half) && values[last]%2 == 1)
+	case RoundingUp:
+		up = r.IsPositive()
+	}
+	if !up {
+		return 0
+	}
+
+	// 繰り上げ (ex. 1 hour 60 minutes は 2 hours)
+	values[last]++
+	for i := last; i > 0; i-- {
+		var ratio uint64
+		switch humanUnits[i] {
+		case UnitSecond, UnitMinute:
+			ratio = 60
+		case UnitHour:
+			ratio = 24
+		case UnitMonth:
+			ratio = 12
+		}
+		if ratio == 0 || values[i] != ratio {
+			break
+		}
+		values[i] = 0
+		values[i-1]++
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package iso8601duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	d := Duration{Years: 1, Months: 2, Days: 3}
+	assert.Equal(t, "1 year, 2 months and 3 days", Humanize(d, HumanizeLong))
+	assert.Equal(t, "1y 2mo 3d", Humanize(d, HumanizeShort))
+	assert.Equal(t, "1y2mo3d", Humanize(d, HumanizeNarrow))
+
+	tests := []struct {
+		name string
+		d    Duration
+		opts HumanizeOptions
+		want string
+	}{
+		{name: "single", d: Duration{Hours: 1}, opts: HumanizeOptions{}, want: "1 hour"},
+		{name: "two", d: Duration{Weeks: 2, Minutes: 1}, opts: HumanizeOptions{}, want: "2 weeks and 1 minute"},
+		{name: "fraction", d: Duration{Seconds: 1, Nanoseconds: 500000000}, opts: HumanizeOptions{}, want: "1.5 seconds"},
+		{name: "sub second", d: Duration{Nanoseconds: 250000000}, opts: HumanizeOptions{Style: HumanizeShort}, want: "0.25s"},
+		{name: "fixed fraction", d: Duration{Seconds: 1}, opts: HumanizeOptions{FractionDigits: 1, FixedFraction: true}, want: "1.0 seconds"},
+		{name: "zero", d: Duration{}, opts: HumanizeOptions{}, want: "0 seconds"},
+		{name: "zero short", d: Duration{Negative: true}, opts: HumanizeOptions{Style: HumanizeShort}, want: "0s"},
+		{name: "negative", d: Duration{Negative: true, Days: 1}, opts: HumanizeOptions{}, want: "minus 1 day"},
+		{name: "negative short", d: Duration{Negative: true, Days: 1, Hours: 2}, opts: HumanizeOptions{Style: HumanizeShort}, want: "-1d 2h"},
+		{name: "comma", d: d, opts: HumanizeOptions{List: ListStyleComma}, want: "1 year, 2 months, 3 days"},
+		{name: "space", d: d, opts: HumanizeOptions{List: ListStyleSpace}, want: "1 year 2 months 3 days"},
+		{name: "short and", d: d, opts: HumanizeOptions{Style: HumanizeShort, List: ListStyleAnd}, want: "1y, 2mo and 3d"},
+		{name: "relative future", d: Duration{Days: 3}, opts: HumanizeOptions{Relative: true}, want: "in 3 days"},
+		{name: "relative past", d: Duration{Negative: true, Days: 3}, opts: HumanizeOptions{Relative: true}, want: "3 days ago"},
+		{name: "relative short", d: Duration{Negative: true, Hours: 1, Minutes: 5}, opts: HumanizeOptions{Style: HumanizeShort, Relative: true}, want: "1h 5m ago"},
+		{name: "relative zero", d: Duration{}, opts: HumanizeOptions{Relative: true}, want: "now"},
+		{name: "max units truncate", d: Duration{Days: 1, Hours: 23, Minutes: 59}, opts: HumanizeOptions{MaxUnits: 1}, want: "1 day"},
+		{name: "max units half up", d: Duration{Days: 1, Hours: 12}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfUp}, want: "2 days"},
+		{name: "max units half even", d: Duration{Days: 2, Hours: 12}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfEven}, want: "2 days"},
+		{name: "max units half even odd", d: Duration{Days: 1, Hours: 12}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfEven}, want: "2 days"},
+		{name: "max units up", d: Duration{Hours: 1, Nanoseconds: 1}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingUp}, want: "2 hours"},
+		{name: "max units skips zero", d: Duration{Days: 1, Minutes: 30, Seconds: 40}, opts: HumanizeOptions{MaxUnits: 2, Rounding: RoundingHalfUp}, want: "1 day and 31 minutes"},
+		{name: "max units carry", d: Duration{Hours: 1, Minutes: 59, Seconds: 45}, opts: HumanizeOptions{MaxUnits: 2, Rounding: RoundingHalfUp}, want: "2 hours"},
+		{name: "max units carry chain", d: Duration{Days: 1, Hours: 23, Minutes: 59, Seconds: 45}, opts: HumanizeOptions{MaxUnits: 3, Rounding: RoundingHalfUp}, want: "2 days"},
+		{name: "max units carry new unit", d: Duration{Days: 1, Minutes: 59, Seconds: 45}, opts: HumanizeOptions{MaxUnits: 2, Rounding: RoundingHalfUp}, want: "1 day and 1 hour"},
+		{name: "max units calendar", d: Duration{Months: 1, Days: 20}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfUp}, want: "2 months"},
+		{name: "max units calendar year", d: Duration{Years: 1, Months: 11, Days: 20}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfUp}, want: "2 years"},
+		{name: "max units calendar carry", d: Duration{Years: 1, Months: 11, Days: 20}, opts: HumanizeOptions{MaxUnits: 2, Rounding: RoundingHalfUp}, want: "2 years"},
+		{name: "max units seconds fraction", d: Duration{Minutes: 1, Seconds: 29, Nanoseconds: 600000000}, opts: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfUp}, want: "1 minute"},
+		{name: "max units fraction kept", d: Duration{Minutes: 1, Seconds: 29, Nanoseconds: 600000000}, opts: HumanizeOptions{MaxUnits: 2}, want: "1 minute and 29.6 seconds"},
+		{name: "max units narrow relative", d: Duration{Negative: true, Years: 2, Months: 3, Days: 4}, opts: HumanizeOptions{Style: HumanizeNarrow, MaxUnits: 2, Relative: true}, want: "2y3mo ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.Humanize(tt.d))
+		})
+	}
+}
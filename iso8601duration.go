@@ -5,6 +5,7 @@ import (
 	"encoding"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"regexp"
 	"strconv"
@@ -33,6 +34,15 @@ var (
 	// ErrUnsupportedNegative マイナス期間未サポート
 	ErrUnsupportedNegative = errors.New("unsupported negative duration")
 
+	// ErrMixedSign 要素ごとに符号が異なる期間は Duration で表現出来ない
+	ErrMixedSign = errors.New("unsupported mixed sign duration")
+
+	// ErrOverflow 変換先の範囲を超えている
+	ErrOverflow = errors.New("duration overflow")
+
+	// ErrPrecisionLoss 変換先の精度で表現出来ない
+	ErrPrecisionLoss = errors.New("duration precision loss")
+
 	one                   = decimal.NewFromInt(1)
 	monthsPerYear         = decimal.NewFromInt(12)
 	hoursPerDay           = decimal.NewFromInt(24)
@@ -41,9 +51,17 @@ var (
 	nanosecondsPerSeconds = decimal.NewFromUint64(uint64(time.Second))
 )
 
+// maxSecondsPerAdd time.Time.Add で一度に加算出来る秒数
+const maxSecondsPerAdd = uint64(math.MaxInt64 / time.Second)
+
+// maxStringLength ISO-8601 Duration書式の最大長 (符号, P, T, 小数点, 10桁の数値と単位 x 7, 小数部9桁)
+const maxStringLength = 4 + 11*7 + 9
+
 // 型チェック
 var (
+	_ fmt.Stringer             = Duration{}
 	_ encoding.TextMarshaler   = Duration{}
+	_ encoding.TextAppender    = Duration{}
 	_ encoding.TextUnmarshaler = (*Duration)(nil)
 	_ json.Marshaler           = Duration{}
 	_ json.Unmarshaler         = (*Duration)(nil)
@@ -136,14 +154,29 @@ func (d Duration) Abs() Duration {
 
 // AddTo は指定日時から期間分経過した日時を返す
 func (d Duration) AddTo(from time.Time) time.Time {
-	timeDuration := time.Duration(d.Hours)*time.Hour + time.Duration(d.Minutes)*time.Minute + time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanoseconds)
+	// 時刻部は time.Duration の範囲 (約292年) を超え得るため、秒とナノ秒に分け、秒は分割して加算する
+	seconds := uint64(d.Hours)*3600 + uint64(d.Minutes)*60 + uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second)
+	nanoseconds := time.Duration(d.Nanoseconds % uint32(time.Second))
+	sign := time.Duration(1)
+	if d.Negative {
+		sign = -1
+	}
+
+	r := d.addDatePart(from)
+	for seconds > 0 {
+		s := min(seconds, maxSecondsPerAdd)
+		r = r.Add(sign * time.Duration(s) * time.Second)
+		seconds -= s
+	}
+	return r.Add(sign * nanoseconds)
+}
 
+// addDatePart は指定日時に日付部 (年月週日) のみを加算した日時を返す
+func (d Duration) addDatePart(from time.Time) time.Time {
 	if d.Negative {
-		r := from.AddDate(-1*int(d.Years), -1*int(d.Months), -1*int(d.Weeks*7+d.Days))
-		return r.Add(-1 * timeDuration)
+		return from.AddDate(-1*int(d.Years), -1*int(d.Months), -1*(int(d.Weeks)*7+int(d.Days)))
 	}
-	r := from.AddDate(int(d.Years), int(d.Months), int(d.Weeks*7+d.Days))
-	return r.Add(timeDuration)
+	return from.AddDate(int(d.Years), int(d.Months), int(d.Weeks)*7+int(d.Days))
 }
 
 // AddToJapan は指定日時から期間分経過した日時を返す (民法第139条,140条,141条,143条に準拠)
@@ -244,61 +277,46 @@ func (d Duration) Normalize() (Duration, bool) {
 	return r, true
 }
 
-func (d *Duration) String() string {
-	if d.IsZero() {
-		return "PT0S"
-	}
+// String はISO-8601 Duration書式の文字列を返す
+// fmt や slog で値としても使用出来るよう、値レシーバとする
+func (d Duration) String() string {
+	return string(d.appendString(make([]byte, 0, maxStringLength)))
+}
 
-	var builder strings.Builder
-	if d.Negative {
-		builder.WriteByte('-')
-	}
-	builder.WriteByte('P')
-	if d.Years != 0 {
-		builder.WriteString(strconv.FormatUint(uint64(d.Years), 10))
-		builder.WriteByte('Y')
-	}
-	if d.Months != 0 {
-		builder.WriteString(strconv.FormatUint(uint64(d.Months), 10))
-		builder.WriteByte('M')
-	}
-	if d.Weeks != 0 {
-		builder.WriteString(strconv.FormatUint(uint64(d.Weeks), 10))
-		builder.WriteByte('W')
+// appendString はISO-8601 Duration書式の文字列を b に追加する
+func (d Duration) appendString(b []byte) []byte {
+	return FormatOptions{}.appendFormat(b, d)
+}
+
+// appendUnit は値が0でない場合、値と単位を b に追加する
+func appendUnit(b []byte, v uint32, designator byte) []byte {
+	if v == 0 {
+		return b
 	}
-	if d.Days != 0 {
-		builder.WriteString(strconv.FormatUint(uint64(d.Days), 10))
-		builder.WriteByte('D')
+	b = strconv.AppendUint(b, uint64(v), 10)
+	return append(b, designator)
+}
+
+// appendFixedFraction はナノ秒を、 digits 桁の小数部として b に追加する
+// 9桁を超える場合は0で埋め、9桁未満の場合は切り捨てる
+func appendFixedFraction(b []byte, nanoseconds uint32, digits int) []byte {
+	var buf [9]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = byte('0' + nanoseconds%10)
+		nanoseconds /= 10
 	}
-	if d.HasTimePart() {
-		builder.WriteByte('T')
-		if d.Hours != 0 {
-			builder.WriteString(strconv.FormatUint(uint64(d.Hours), 10))
-			builder.WriteByte('H')
-		}
-		if d.Minutes != 0 {
-			builder.WriteString(strconv.FormatUint(uint64(d.Minutes), 10))
-			builder.WriteByte('M')
-		}
-		if d.Nanoseconds != 0 {
-			// 小数以下
-			sec, nano := decimal.NewFromUint64(uint64(d.Nanoseconds)).QuoRem(nanosecondsPerSeconds, 0)
-			nanoStr := nano.String()
-			builder.WriteString(sec.Add(decimal.NewFromUint64(uint64(d.Seconds))).String())
-			builder.WriteByte('.')
-			builder.Write(bytes.Repeat([]byte{'0'}, 9-len(nanoStr)))
-			builder.WriteString(strings.TrimRight(nanoStr, "0"))
-			builder.WriteByte('S')
-		} else if d.Seconds != 0 {
-			builder.WriteString(strconv.FormatUint(uint64(d.Seconds), 10))
-			builder.WriteByte('S')
-		}
+	b = append(b, buf[:min(digits, len(buf))]...)
+	for range digits - len(buf) {
+		b = append(b, '0')
 	}
-
-	return builder.String()
+	return b
 }
 
 func (d *Duration) UnmarshalText(data []byte) error {
+	if simple, ok := parseSimple(data); ok {
+		*d = simple
+		return nil
+	}
 	t, err := ParseString(string(data))
 	if err != nil {
 		return err
@@ -311,16 +329,41 @@ func (d *Duration) UnmarshalText(data []byte) error {
 }
 
 func (d Duration) MarshalText() ([]byte, error) {
-	return []byte(d.String()), nil
+	return d.appendString(make([]byte, 0, maxStringLength)), nil
+}
+
+// AppendText は encoding.TextAppender を実装する
+func (d Duration) AppendText(b []byte) ([]byte, error) {
+	return d.appendString(b), nil
 }
 
+// UnmarshalJSON は json.Unmarshaler を実装する
+// ISO-8601 Duration書式の文字列に加えて、要素ごとのオブジェクト (ObjectDuration) と秒数 (SecondsDuration) を受け付ける
 func (d *Duration) UnmarshalJSON(data []byte) error {
-	dec := json.NewDecoder(bytes.NewBuffer(data))
-	var s string
-	if err := dec.Decode(&s); err != nil {
-		return err
+	data = bytes.TrimSpace(data)
+	var t *Duration
+	var err error
+	switch {
+	case len(data) == 0:
+		return ErrBadFormat
+	case data[0] == '{':
+		t, err = unmarshalJSONObject(data)
+	case data[0] == '-' || ('0' <= data[0] && data[0] <= '9'):
+		t, err = unmarshalJSONSeconds(data)
+	case len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' && bytes.IndexByte(data, '\\') < 0:
+		// エスケープを含まない場合、デコーダを介さずにパースする
+		if simple, ok := parseSimple(data[1 : len(data)-1]); ok {
+			*d = simple
+			return nil
+		}
+		t, err = ParseString(string(data[1 : len(data)-1]))
+	default:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		t, err = ParseString(s)
 	}
-	t, err := ParseString(s)
 	if err != nil {
 		return err
 	}
@@ -332,14 +375,15 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 }
 
 func (d Duration) MarshalJSON() ([]byte, error) {
-	var b bytes.Buffer
-	enc := json.NewEncoder(&b)
-	s := d.String()
-	err := enc.Encode(s)
-	if err != nil {
-		return nil, err
-	}
-	return b.Bytes(), nil
+	return d.appendJSON(make([]byte, 0, maxStringLength+2)), nil
+}
+
+// appendJSON はISO-8601 Duration書式の JSON 文字列を b に追加する
+// 書式はエスケープが必要な文字を含まないため、そのまま出力する
+func (d Duration) appendJSON(b []byte) []byte {
+	b = append(b, '"')
+	b = d.appendString(b)
+	return append(b, '"')
 }
 
 func addFrac(base, frac decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
@@ -348,6 +392,14 @@ func addFrac(base, frac decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
 
 // ParseString は文字列をISO-8601 Duration書式としてパースし、 Duration を返す
 func ParseString(s string) (*Duration, error) {
+	if d, ok := parseSimple(s); ok {
+		return &d, nil
+	}
+	return parsePattern(s)
+}
+
+// parsePattern は正規表現で、小数を含む全ての書式をパースする
+func parsePattern(s string) (*Duration, error) {
 	groups := iso8601Pattern.FindStringSubmatch(s)
 	if groups == nil {
 		return nil, ErrBadFormat
@@ -419,3 +471,101 @@ func ParseString(s string) (*Duration, error) {
 		Nanoseconds: uint32(nanoSeconds.IntPart()),
 	}, nil
 }
+
+// parseSimple は小数を秒のみに含む、一般的な書式 (ex. P1DT2H, PT1.5S) を、正規表現と decimal を使わずにパースする
+// 各要素が uint32 の範囲を超える場合や、秒以外に小数を含む場合など、扱えない書式は false を返す (ParseString でパースする)
+func parseSimple[T string | []byte](s T) (Duration, bool) {
+	var d Duration
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		d.Negative = true
+		i++
+	}
+	if i >= len(s) || s[i] != 'P' {
+		return Duration{}, false
+	}
+	i++
+
+	// 書式に現れる順に並べた単位 (時刻部の M は分とする)
+	const designators = "YMWDTHMS"
+	const timeDesignator = 4
+	next := 0
+	for i < len(s) {
+		if s[i] == 'T' {
+			if next > timeDesignator {
+				return Duration{}, false
+			}
+			next = timeDesignator + 1
+			i++
+			continue
+		}
+
+		var value uint64
+		start := i
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			value = value*10 + uint64(s[i]-'0')
+			if value > math.MaxUint32 {
+				return Duration{}, false
+			}
+			i++
+		}
+		if i == start || i >= len(s) {
+			return Duration{}, false
+		}
+
+		// 秒の小数部 (9桁まで)
+		var nanoseconds uint32
+		hasFraction := s[i] == '.' || s[i] == ','
+		if hasFraction {
+			i++
+			start = i
+			scale := uint32(time.Second)
+			for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+				if i-start >= 9 {
+					return Duration{}, false
+				}
+				scale /= 10
+				nanoseconds += uint32(s[i]-'0') * scale
+				i++
+			}
+			if i == start || i >= len(s) || s[i] != 'S' {
+				return Duration{}, false
+			}
+		}
+
+		// 単位は、前の単位より後に現れる必要がある (時刻部の単位は T の後のみ)
+		timePart := next > timeDesignator
+		var unit int
+		if timePart {
+			unit = strings.IndexByte(designators[next:], s[i])
+		} else {
+			unit = strings.IndexByte(designators[next:timeDesignator], s[i])
+		}
+		if unit < 0 {
+			return Duration{}, false
+		}
+		next += unit + 1
+
+		v := uint32(value)
+		switch designators[next-1] {
+		case 'Y':
+			d.Years = v
+		case 'M':
+			if timePart {
+				d.Minutes = v
+			} else {
+				d.Months = v
+			}
+		case 'W':
+			d.Weeks = v
+		case 'D':
+			d.Days = v
+		case 'H':
+			d.Hours = v
+		case 'S':
+			d.Seconds, d.Nanoseconds = v, nanoseconds
+		}
+		i++
+	}
+	return d, true
+}
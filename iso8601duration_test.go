@@ -88,6 +88,56 @@ func TestParseString(t *testing.T) {
 	})
 }
 
+func TestParseSimple(t *testing.T) {
+	tests := []struct {
+		s    string
+		ok   bool
+		want Duration
+	}{
+		{s: "P1DT2H", ok: true, want: Duration{Days: 1, Hours: 2}},
+		{s: "-P1Y2M3W4DT5H6M7.5S", ok: true, want: Duration{Negative: true, Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 500000000}},
+		{s: "PT1M", ok: true, want: Duration{Minutes: 1}},
+		{s: "PT0,000000001S", ok: true, want: Duration{Nanoseconds: 1}},
+		{s: "PT", ok: true},
+		{s: "P4294967295D", ok: true, want: Duration{Days: math.MaxUint32}},
+		// ParseString でパースする
+		{s: "P4294967296D"},
+		{s: "PT0.0000000001S"},
+		{s: "P1.5D"},
+		// 不正な書式
+		{s: ""},
+		{s: "P1D1Y"},
+		{s: "P1H"},
+		{s: "PT1D"},
+		{s: "P1DT1HT"},
+		{s: "P1.5"},
+		{s: "PT.5S"},
+		{s: "P-1D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			actual, ok := parseSimple(tt.s)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	// プロパティテスト (正規表現によるパースと一致する)
+	rapid.Check(t, func(t *rapid.T) {
+		tokens := []string{"1", "23", "0", "4294967295", "4294967296", ".5", ",25", ".123456789", ".", "Y", "M", "W", "D", "T", "H", "S", "-", "P"}
+		s := rapid.SampledFrom([]string{"P", "-P", ""}).Draw(t, "prefix") +
+			strings.Join(rapid.SliceOfN(rapid.SampledFrom(tokens), 0, 12).Draw(t, "tokens"), "")
+
+		actual, ok := parseSimple(s)
+		if !ok {
+			return
+		}
+		expect, err := parsePattern(s)
+		assert.Nil(t, err)
+		assert.Equal(t, *expect, actual)
+	})
+}
+
 func TestTextMarshal(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		expect := Duration{
@@ -192,6 +242,17 @@ func TestAddTo(t *testing.T) {
 	base := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
 	actual := sut.AddTo(base)
 	assert.Equal(t, time.Date(2026, 12, 10+21+4, 5, 6, 7, 800*1000*1000, time.UTC), actual)
+
+	// time.Duration の範囲 (約292年) を超える時刻部
+	sut = &Duration{Hours: 3000000, Minutes: math.MaxUint32, Nanoseconds: 1500000000}
+	actual = sut.AddTo(base)
+	expect := base.Add(1500000 * time.Hour).Add(1500000 * time.Hour)
+	for range 60 {
+		expect = expect.Add(math.MaxUint32 * time.Second)
+	}
+	assert.Equal(t, expect.Add(1500*time.Millisecond), actual)
+	assert.Equal(t, base, sut.Negate().AddTo(actual))
+	assert.Equal(t, sut.nanosecondsAt(base), nanosecondsBetween(base, actual))
 }
 
 func TestAddToJapan(t *testing.T) {
@@ -0,0 +1,100 @@
+package iso8601duration
+
+import (
+	"maps"
+	"strings"
+)
+
+// JapaneseMonthStyle は月の助数詞の表記を表す
+type JapaneseMonthStyle int
+
+const (
+	// JapaneseMonthKa か月 (ex. 3か月)
+	JapaneseMonthKa JapaneseMonthStyle = iota
+	// JapaneseMonthKe ヶ月 (ex. 3ヶ月)
+	JapaneseMonthKe
+)
+
+// JapaneseOptions は日本語の書式の出力方法を表す
+// HumanizeOptions の Style と List は使用しない
+type JapaneseOptions struct {
+	HumanizeOptions
+	// Month 月の助数詞の表記
+	Month JapaneseMonthStyle
+	// Kanji 数字を漢数字とする (ex. 十二時間三十分)
+	Kanji bool
+}
+
+var (
+	kanjiDigits     = [...]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	kanjiSmallUnits = [...]string{"", "十", "百", "千"}
+	kanjiLargeUnits = [...]string{"", "万", "億", "兆", "京"}
+)
+
+// HumanizeJapanese は期間を日本語の書式 (ex. 1年2か月3日) に変換する
+func HumanizeJapanese(d Duration) string {
+	return JapaneseOptions{}.Humanize(d)
+}
+
+// Humanize は期間を日本語の書式に変換する
+// Relative の場合は、 Negative であれば「前」、それ以外は「後」とする (ex. 3日後、3日前)
+func (o JapaneseOptions) Humanize(d Duration) string {
+	l := localeJapanese
+	if o.Month == JapaneseMonthKe {
+		units := maps.Clone(japaneseUnits)
+		units[UnitMonth] = otherOnly("{0}ヶ月")
+		l.Units = [3]map[Unit]UnitPattern{units, units, units}
+	}
+	if o.Kanji {
+		l.Numerals = kanjiNumber
+	}
+	opts := o.HumanizeOptions
+	opts.Style, opts.List = HumanizeLong, ListStyleDefault
+	return l.Humanize(d, opts)
+}
+
+// kanjiNumber は10進数の文字列を漢数字に変換する
+// 整数部は万進の位取り (ex. 一万二千三百四) 、小数部は1桁ずつ変換し、小数点は「・」とする
+func kanjiNumber(s string) string {
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+
+	var builder strings.Builder
+	if strings.Trim(integer, "0") == "" {
+		builder.WriteString(kanjiDigits[0])
+	} else {
+		// 下位から4桁ずつ区切る
+		var groups []string
+		for len(integer) > 0 {
+			n := max(len(integer)-4, 0)
+			groups = append(groups, integer[n:])
+			integer = integer[:n]
+		}
+		for i := len(groups) - 1; i >= 0; i-- {
+			group := groups[i]
+			if strings.Trim(group, "0") == "" {
+				continue
+			}
+			for j, c := range group {
+				digit := c - '0'
+				if digit == 0 {
+					continue
+				}
+				place := len(group) - 1 - j
+				// 十、百、千の前の一は省略する
+				if digit != 1 || place == 0 {
+					builder.WriteString(kanjiDigits[digit])
+				}
+				builder.WriteString(kanjiSmallUnits[place])
+			}
+			builder.WriteString(kanjiLargeUnits[i])
+		}
+	}
+
+	if hasFraction {
+		builder.WriteString("・")
+		for _, c := range fraction {
+			builder.WriteString(kanjiDigits[c-'0'])
+		}
+	}
+	return builder.String()
+}
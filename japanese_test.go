@@ -0,0 +1,65 @@
+package iso8601duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeJapanese(t *testing.T) {
+	assert.Equal(t, "1年2か月3日", HumanizeJapanese(Duration{Years: 1, Months: 2, Days: 3}))
+
+	tests := []struct {
+		name string
+		d    Duration
+		opts JapaneseOptions
+		want string
+	}{
+		{name: "ke", d: Duration{Months: 3}, opts: JapaneseOptions{Month: JapaneseMonthKe}, want: "3ヶ月"},
+		{name: "weeks", d: Duration{Weeks: 2, Days: 1}, opts: JapaneseOptions{}, want: "2週間1日"},
+		{name: "time", d: Duration{Hours: 2, Minutes: 30, Seconds: 15}, opts: JapaneseOptions{}, want: "2時間30分15秒"},
+		{name: "fraction", d: Duration{Seconds: 1, Nanoseconds: 500000000}, opts: JapaneseOptions{}, want: "1.5秒"},
+		{name: "zero", d: Duration{}, opts: JapaneseOptions{}, want: "0秒"},
+		{name: "negative", d: Duration{Negative: true, Days: 1}, opts: JapaneseOptions{}, want: "マイナス1日"},
+		{name: "after", d: Duration{Days: 3}, opts: JapaneseOptions{HumanizeOptions: HumanizeOptions{Relative: true}}, want: "3日後"},
+		{name: "before", d: Duration{Negative: true, Days: 3}, opts: JapaneseOptions{HumanizeOptions: HumanizeOptions{Relative: true}}, want: "3日前"},
+		{name: "now", d: Duration{}, opts: JapaneseOptions{HumanizeOptions: HumanizeOptions{Relative: true}}, want: "今"},
+		{name: "max units", d: Duration{Months: 1, Days: 20}, opts: JapaneseOptions{HumanizeOptions: HumanizeOptions{MaxUnits: 1, Rounding: RoundingHalfUp}}, want: "2か月"},
+		{name: "kanji", d: Duration{Hours: 12, Minutes: 30}, opts: JapaneseOptions{Kanji: true}, want: "十二時間三十分"},
+		{name: "kanji relative", d: Duration{Negative: true, Years: 1, Months: 10}, opts: JapaneseOptions{Kanji: true, Month: JapaneseMonthKe, HumanizeOptions: HumanizeOptions{Relative: true}}, want: "一年十ヶ月前"},
+		{name: "kanji fraction", d: Duration{Seconds: 1, Nanoseconds: 50000000}, opts: JapaneseOptions{Kanji: true}, want: "一・〇五秒"},
+		{name: "kanji zero", d: Duration{}, opts: JapaneseOptions{Kanji: true}, want: "〇秒"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.Humanize(tt.d))
+		})
+	}
+}
+
+func TestKanjiNumber(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "0", want: "〇"},
+		{s: "1", want: "一"},
+		{s: "10", want: "十"},
+		{s: "11", want: "十一"},
+		{s: "20", want: "二十"},
+		{s: "105", want: "百五"},
+		{s: "365", want: "三百六十五"},
+		{s: "1000", want: "千"},
+		{s: "2024", want: "二千二十四"},
+		{s: "10000", want: "一万"},
+		{s: "12345", want: "一万二千三百四十五"},
+		{s: "100000001", want: "一億一"},
+		{s: "4294967295", want: "四十二億九千四百九十六万七千二百九十五"},
+		{s: "0.5", want: "〇・五"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, kanjiNumber(tt.s))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package iso8601duration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	secondsPerHour = decimal.NewFromInt(3600)
+	maxUint32      = decimal.NewFromInt(math.MaxUint32)
+)
+
+// 型チェック
+var (
+	_ json.Marshaler   = ObjectDuration{}
+	_ json.Unmarshaler = (*ObjectDuration)(nil)
+	_ json.Marshaler   = SecondsDuration{}
+	_ json.Unmarshaler = (*SecondsDuration)(nil)
+)
+
+// ObjectDuration は JSON で要素ごとのオブジェクト (ex. {"years":1,"months":2,...,"negative":false}) として出力する Duration
+// デコード時は Duration と同様に、文字列・オブジェクト・秒数の全てを受け付ける
+type ObjectDuration struct {
+	Duration
+}
+
+// SecondsDuration は JSON で秒数 (ex. 90, 1.5) として出力する Duration
+// 1日は24時間として換算し、年月を含む場合は ErrCalendarComponent を返す
+// デコード時は Duration と同様に、文字列・オブジェクト・秒数の全てを受け付ける
+type SecondsDuration struct {
+	Duration
+}
+
+// jsonObject は JSON のオブジェクト表現
+type jsonObject struct {
+	Years       uint32 `json:"years"`
+	Months      uint32 `json:"months"`
+	Weeks       uint32 `json:"weeks"`
+	Days        uint32 `json:"days"`
+	Hours       uint32 `json:"hours"`
+	Minutes     uint32 `json:"minutes"`
+	Seconds     uint32 `json:"seconds"`
+	Nanoseconds uint32 `json:"nanoseconds"`
+	Negative    bool   `json:"negative"`
+}
+
+// MarshalJSON は json.Marshaler を実装する
+func (d ObjectDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.toJSONObject())
+}
+
+// MarshalJSON は json.Marshaler を実装する
+func (d SecondsDuration) MarshalJSON() ([]byte, error) {
+	seconds, ok := d.TotalSeconds()
+	if !ok {
+		return nil, ErrCalendarComponent
+	}
+	return []byte(seconds.String()), nil
+}
+
+func (d Duration) toJSONObject() jsonObject {
+	return jsonObject{
+		Years:       d.Years,
+		Months:      d.Months,
+		Weeks:       d.Weeks,
+		Days:        d.Days,
+		Hours:       d.Hours,
+		Minutes:     d.Minutes,
+		Seconds:     d.Seconds,
+		Nanoseconds: d.Nanoseconds,
+		Negative:    d.Negative,
+	}
+}
+
+// unmarshalJSONObject はオブジェクト表現をデコードする
+// 未知のキーを含む場合は ErrBadFormat を返す
+func unmarshalJSONObject(data []byte) (*Duration, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var obj jsonObject
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadFormat, err)
+	}
+	return &Duration{
+		Negative:    obj.Negative,
+		Years:       obj.Years,
+		Months:      obj.Months,
+		Weeks:       obj.Weeks,
+		Days:        obj.Days,
+		Hours:       obj.Hours,
+		Minutes:     obj.Minutes,
+		Seconds:     obj.Seconds,
+		Nanoseconds: obj.Nanoseconds,
+	}, nil
+}
+
+// unmarshalJSONSeconds は秒数表現をデコードする (ナノ秒未満は切り捨てる)
+// 時分秒に分割し、時が Duration の範囲 (uint32) を超える場合は ErrOverflow を返す
+func unmarshalJSONSeconds(data []byte) (*Duration, error) {
+	seconds, err := decimal.NewFromString(string(data))
+	if err != nil {
+		return nil, ErrBadFormat
+	}
+	ns := seconds.Mul(nanosecondsPerSeconds).Truncate(0)
+	s, nanos := ns.Abs().QuoRem(nanosecondsPerSeconds, 0)
+	hours, rest := s.QuoRem(secondsPerHour, 0)
+	if hours.GreaterThan(maxUint32) {
+		return nil, ErrOverflow
+	}
+	return &Duration{
+		Negative:    ns.IsNegative(),
+		Hours:       uint32(hours.IntPart()),
+		Minutes:     uint32(rest.IntPart() / 60),
+		Seconds:     uint32(rest.IntPart() % 60),
+		Nanoseconds: uint32(nanos.IntPart()),
+	}, nil
+}
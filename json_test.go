@@ -0,0 +1,131 @@
+package iso8601duration
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectDurationJSON(t *testing.T) {
+	sut := ObjectDuration{Duration{Negative: true, Years: 1, Months: 2, Days: 3, Nanoseconds: 4}}
+	actual, err := json.Marshal(sut)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"years":1,"months":2,"weeks":0,"days":3,"hours":0,"minutes":0,"seconds":0,"nanoseconds":4,"negative":true}`, string(actual))
+
+	var decoded ObjectDuration
+	assert.Nil(t, json.Unmarshal(actual, &decoded))
+	assert.Equal(t, sut, decoded)
+}
+
+func TestSecondsDurationJSON(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     string
+	}{
+		{duration: "PT0S", want: "0"},
+		{duration: "PT90S", want: "90"},
+		{duration: "PT1.5S", want: "1.5"},
+		{duration: "-PT0.000000001S", want: "-0.000000001"},
+		{duration: "P1DT1H", want: "90000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual, err := json.Marshal(SecondsDuration{*sut})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(actual))
+		})
+	}
+
+	// 年月
+	_, err := json.Marshal(SecondsDuration{Duration{Months: 1}})
+	assert.ErrorIs(t, err, ErrCalendarComponent)
+}
+
+func TestUnmarshalJSONForms(t *testing.T) {
+	tests := []struct {
+		json string
+		want Duration
+	}{
+		{json: `"PT1H30M"`, want: Duration{Hours: 1, Minutes: 30}},
+		{json: `{"days":3,"negative":true}`, want: Duration{Negative: true, Days: 3}},
+		{json: ` {"years":1,"months":2} `, want: Duration{Years: 1, Months: 2}},
+		{json: `5400`, want: Duration{Hours: 1, Minutes: 30}},
+		{json: `1.5`, want: Duration{Seconds: 1, Nanoseconds: 500000000}},
+		{json: `-0.5`, want: Duration{Negative: true, Nanoseconds: 500000000}},
+		{json: `1e3`, want: Duration{Minutes: 16, Seconds: 40}},
+		{json: `0.0000000019`, want: Duration{Nanoseconds: 1}},
+		// google.protobuf.Duration の範囲 (約10000年) を超える値
+		{json: `315576000001`, want: Duration{Hours: 87660000, Seconds: 1}},
+		{json: `-15461882265599.5`, want: Duration{Negative: true, Hours: math.MaxUint32, Minutes: 59, Seconds: 59, Nanoseconds: 500000000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var actual Duration
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &actual))
+			assert.Equal(t, tt.want, actual)
+
+			// ラッパー型でも全ての表現を受け付ける
+			var object ObjectDuration
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &object))
+			assert.Equal(t, tt.want, object.Duration)
+			var seconds SecondsDuration
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &seconds))
+			assert.Equal(t, tt.want, seconds.Duration)
+		})
+	}
+
+	// フォーマット不正
+	invalid := []string{
+		`""`,
+		`"1 day"`,
+		`{"day":3}`,
+		`{"days":-3}`,
+		`{"days":"3"}`,
+		`true`,
+	}
+	for _, s := range invalid {
+		t.Run(s, func(t *testing.T) {
+			var actual Duration
+			assert.Error(t, json.Unmarshal([]byte(s), &actual))
+		})
+	}
+
+	var actual Duration
+	assert.ErrorIs(t, actual.UnmarshalJSON([]byte(`1e20`)), ErrOverflow)
+	assert.ErrorIs(t, actual.UnmarshalJSON([]byte(`15461882265600`)), ErrOverflow)
+	assert.ErrorIs(t, actual.UnmarshalJSON([]byte(`{"day":3}`)), ErrBadFormat)
+}
+
+func TestJSONAllocs(t *testing.T) {
+	sut := Duration{Days: 1, Hours: 2, Minutes: 3, Seconds: 4, Nanoseconds: 500000000}
+
+	// バッファのみ確保する
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = sut.MarshalJSON()
+	})
+	assert.LessOrEqual(t, allocs, float64(1))
+
+	allocs = testing.AllocsPerRun(100, func() {
+		_, _ = sut.AppendText(make([]byte, 0, 64))
+	})
+	assert.Equal(t, float64(0), allocs)
+
+	// エスケープを含まない文字列は、デコーダと正規表現を介さずにパースする
+	data := []byte(`"P1DT2H3M4.5S"`)
+	var actual Duration
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = actual.UnmarshalJSON(data)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, sut, actual)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = actual.UnmarshalText(data[1 : len(data)-1])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,59 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package iso8601duration
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+// 型チェック
+var (
+	_ json.MarshalerTo     = Duration{}
+	_ json.UnmarshalerFrom = (*Duration)(nil)
+	_ json.MarshalerTo     = ObjectDuration{}
+	_ json.MarshalerTo     = SecondsDuration{}
+	_ json.UnmarshalerFrom = (*LenientDuration)(nil)
+)
+
+// MarshalJSONTo は json.MarshalerTo (encoding/json/v2) を実装する
+func (d Duration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var buf [maxStringLength + 2]byte
+	return enc.WriteValue(d.appendJSON(buf[:0]))
+}
+
+// UnmarshalJSONFrom は json.UnmarshalerFrom (encoding/json/v2) を実装する
+// UnmarshalJSON と同様に、文字列・オブジェクト・秒数の全てを受け付ける
+func (d *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalJSON(val)
+}
+
+// MarshalJSONTo は json.MarshalerTo (encoding/json/v2) を実装する
+// 埋め込んだ Duration の MarshalJSONTo が優先されないよう、オブジェクト表現を出力する
+func (d ObjectDuration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return json.MarshalEncode(enc, d.toJSONObject())
+}
+
+// MarshalJSONTo は json.MarshalerTo (encoding/json/v2) を実装する
+// 埋め込んだ Duration の MarshalJSONTo が優先されないよう、秒数表現を出力する
+func (d SecondsDuration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := d.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom は json.UnmarshalerFrom (encoding/json/v2) を実装する
+// 埋め込んだ Duration の UnmarshalJSONFrom が優先されないよう、 UnmarshalJSON でパースする
+func (d *LenientDuration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalJSON(val)
+}
@@ -0,0 +1,89 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package iso8601duration
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestJSONv2Marshal(t *testing.T) {
+	type payload struct {
+		Retention Duration        `json:"retention"`
+		Object    ObjectDuration  `json:"object"`
+		Seconds   SecondsDuration `json:"seconds"`
+	}
+	sut := payload{
+		Retention: Duration{Days: 30},
+		Object:    ObjectDuration{Duration{Negative: true, Years: 1}},
+		Seconds:   SecondsDuration{Duration{Minutes: 1, Nanoseconds: 500000000}},
+	}
+	actual, err := json.Marshal(sut)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"retention":"P30D","object":{"years":1,"months":0,"weeks":0,"days":0,"hours":0,"minutes":0,"seconds":0,"nanoseconds":0,"negative":true},"seconds":60.5}`, string(actual))
+
+	var decoded payload
+	assert.Nil(t, json.Unmarshal(actual, &decoded))
+	assert.Equal(t, sut, decoded)
+
+	// エスケープを含む文字列
+	var d Duration
+	assert.Nil(t, json.Unmarshal([]byte(`"PT\u0031S"`), &d))
+	assert.Equal(t, Duration{Seconds: 1}, d)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"1\u0020day"`), &d), ErrBadFormat)
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		expect := Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32().Draw(t, "years"),
+			Months:      rapid.Uint32().Draw(t, "months"),
+			Weeks:       rapid.Uint32().Draw(t, "weeks"),
+			Days:        rapid.Uint32().Draw(t, "days"),
+			Hours:       rapid.Uint32().Draw(t, "hours"),
+			Minutes:     rapid.Uint32().Draw(t, "minutes"),
+			Seconds:     rapid.Uint32().Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32Max(999999999).Draw(t, "nanoseconds"),
+		}
+		if expect.IsZero() {
+			expect.Negative = false
+		}
+
+		b, err := json.Marshal(expect)
+		assert.Nil(t, err)
+		var actual Duration
+		assert.Nil(t, json.Unmarshal(b, &actual))
+		assert.Equal(t, expect, actual)
+	})
+}
+
+func TestJSONv2LenientDuration(t *testing.T) {
+	var v struct {
+		A LenientDuration `json:"a"`
+		B LenientDuration `json:"b"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":"P1D","b":"7d12h"}`), &v))
+	assert.Equal(t, Duration{Days: 1}, v.A.Duration)
+	assert.Equal(t, Duration{Days: 7, Hours: 12}, v.B.Duration)
+
+	actual, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"P1D","b":"P7DT12H"}`, string(actual))
+}
+
+func TestJSONv2Allocs(t *testing.T) {
+	// AllocsPerRun は1回の予備実行を含むため、実行回数より1つ多く値を並べる
+	const runs = 100
+	dec := jsontext.NewDecoder(strings.NewReader(strings.Repeat(`"P1DT2H3M4.5S" `, runs+1)))
+	var actual Duration
+	allocs := testing.AllocsPerRun(runs, func() {
+		_ = actual.UnmarshalJSONFrom(dec)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, Duration{Days: 1, Hours: 2, Minutes: 3, Seconds: 4, Nanoseconds: 500000000}, actual)
+}
@@ -0,0 +1,288 @@
+package iso8601duration
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PluralCategory は CLDR の複数形カテゴリを表す
+type PluralCategory int
+
+const (
+	// PluralOther other
+	PluralOther PluralCategory = iota
+	// PluralZero zero
+	PluralZero
+	// PluralOne one
+	PluralOne
+	// PluralTwo two
+	PluralTwo
+	// PluralFew few
+	PluralFew
+	// PluralMany many
+	PluralMany
+)
+
+var (
+	// ErrUnknownLocale 登録されていない言語
+	ErrUnknownLocale = errors.New("unknown locale")
+
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{
+		"en": &localeEnglish,
+		"ja": &localeJapanese,
+		"de": &localeGerman,
+		"fr": &localeFrench,
+		"zh": &localeChinese,
+		"ko": &localeKorean,
+	}
+)
+
+// UnitPattern は複数形カテゴリごとの単位のパターン ({0} に値を埋め込む、 ex. {0} Jahre)
+// 該当するカテゴリがない場合は PluralOther を使用する
+type UnitPattern map[PluralCategory]string
+
+// ListPattern は CLDR のリストパターン ({0}, {1} に要素を埋め込む)
+type ListPattern struct {
+	// Start 3要素以上の場合の、最初の要素の連結
+	Start string
+	// Middle 3要素以上の場合の、途中の要素の連結
+	Middle string
+	// End 3要素以上の場合の、最後の要素の連結
+	End string
+	// Pair 2要素の場合の連結
+	Pair string
+}
+
+// Locale は言語ごとの読みやすい書式のデータ
+// 配列は HumanizeStyle の順 (HumanizeLong, HumanizeShort, HumanizeNarrow) とする
+type Locale struct {
+	// Units 単位ごとのパターン
+	Units [3]map[Unit]UnitPattern
+	// RelativeUnits 相対的な表現での単位ごとのパターン (nil の場合は Units を使用する)
+	// 格変化により形が変わる言語で指定する (ex. de vor 3 Tagen)
+	RelativeUnits [3]map[Unit]UnitPattern
+	// Lists 連結方法ごとのパターン
+	Lists map[ListStyle]ListPattern
+	// DefaultLists ListStyleDefault の場合の連結方法
+	DefaultLists [3]ListStyle
+	// Plural 値 (ex. 1, 1.5) の複数形カテゴリを返す
+	Plural func(value string) PluralCategory
+	// Numerals 値 (ex. 1.5) を、その言語の数字に変換する (nil の場合は変換しない)
+	Numerals func(value string) string
+	// DecimalSeparator 小数点
+	DecimalSeparator string
+	// Negative 負の期間のパターン
+	Negative [3]string
+	// Future 未来の相対的な表現のパターン (ex. in {0})
+	Future string
+	// Past 過去の相対的な表現のパターン (ex. {0} ago)
+	Past string
+	// Now ゼロ値の相対的な表現
+	Now string
+	// ParseUnits ParseHuman で、 Units のパターン以外に受け付ける単位の表記 (ex. hr, mins)
+	ParseUnits map[string]Unit
+	// ParseIgnore ParseHuman で、 Lists の接続詞以外に無視する語
+	ParseIgnore []string
+}
+
+// RegisterLocale は言語タグ (ex. de, pt-BR) に読みやすい書式のデータを登録する
+// 登録済みの場合は上書きする
+// l の複製を登録するため、登録後に l を変更しても影響しない
+func RegisterLocale(tag string, l *Locale) {
+	c := l.clone()
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[normalizeLocaleTag(tag)] = &c
+}
+
+// LookupLocale は言語タグに対応する読みやすい書式のデータの複製を返す
+// 見つからない場合、末尾のサブタグを除いて検索する (ex. de-AT は de)
+// 複製を返すため、返り値を変更しても登録済みのデータには影響しない
+func LookupLocale(tag string) (*Locale, bool) {
+	l, ok := lookupLocale(tag)
+	if !ok {
+		return nil, false
+	}
+	c := l.clone()
+	return &c, true
+}
+
+// lookupLocale は言語タグに対応する、登録済みの読みやすい書式のデータを返す
+// 返り値は変更してはならない
+func lookupLocale(tag string) (*Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	tag = normalizeLocaleTag(tag)
+	for {
+		if l, ok := locales[tag]; ok {
+			return l, true
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			return nil, false
+		}
+		tag = tag[:i]
+	}
+}
+
+// englishLocale は英語の読みやすい書式のデータを返す (RegisterLocale で上書き出来る)
+func englishLocale() *Locale {
+	if l, ok := lookupLocale("en"); ok {
+		return l
+	}
+	return &localeEnglish
+}
+
+// HumanizeLocale は期間を、言語タグに対応する言語の読みやすい書式に変換する
+func HumanizeLocale(d Duration, tag string, opts HumanizeOptions) (string, error) {
+	l, ok := lookupLocale(tag)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownLocale, tag)
+	}
+	return l.Humanize(d, opts), nil
+}
+
+// clone はマップとスライスを含めて複製した Locale を返す
+func (l *Locale) clone() Locale {
+	c := *l
+	for i := range c.Units {
+		c.Units[i] = cloneUnitPatterns(l.Units[i])
+		c.RelativeUnits[i] = cloneUnitPatterns(l.RelativeUnits[i])
+	}
+	c.Lists = maps.Clone(l.Lists)
+	c.ParseUnits = maps.Clone(l.ParseUnits)
+	c.ParseIgnore = slices.Clone(l.ParseIgnore)
+	return c
+}
+
+// cloneUnitPatterns は単位ごとのパターンを複製する
+func cloneUnitPatterns(units map[Unit]UnitPattern) map[Unit]UnitPattern {
+	if units == nil {
+		return nil
+	}
+	c := make(map[Unit]UnitPattern, len(units))
+	for unit, pattern := range units {
+		c[unit] = maps.Clone(pattern)
+	}
+	return c
+}
+
+// normalizeLocaleTag は言語タグを小文字、 - 区切りに変換する
+func normalizeLocaleTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+}
+
+// Humanize は期間を読みやすい書式に変換する
+func (l *Locale) Humanize(d Duration, opts HumanizeOptions) string {
+	style := opts.Style
+	if style < HumanizeLong || style > HumanizeNarrow {
+		style = HumanizeLong
+	}
+	if opts.Relative && d.IsZero() {
+		return l.Now
+	}
+
+	units := l.Units[style]
+	if opts.Relative && l.RelativeUnits[style] != nil {
+		units = l.RelativeUnits[style]
+	}
+	parts := opts.parts(d)
+	items := make([]string, len(parts))
+	for i, p := range parts {
+		pattern := units[p.unit]
+		s, ok := pattern[l.plural(p.value)]
+		if !ok {
+			s = pattern[PluralOther]
+		}
+		value := p.value
+		if l.Numerals != nil {
+			value = l.Numerals(value)
+		}
+		if l.DecimalSeparator != "" {
+			value = strings.Replace(value, ".", l.DecimalSeparator, 1)
+		}
+		items[i] = strings.Replace(s, "{0}", value, 1)
+	}
+
+	list := opts.List
+	if list == ListStyleDefault {
+		list = l.DefaultLists[style]
+	}
+	s := l.Lists[list].join(items)
+
+	switch {
+	case opts.Relative && d.Negative:
+		return strings.Replace(l.Past, "{0}", s, 1)
+	case opts.Relative:
+		return strings.Replace(l.Future, "{0}", s, 1)
+	case d.Negative && !d.IsZero():
+		return strings.Replace(l.Negative[style], "{0}", s, 1)
+	default:
+		return s
+	}
+}
+
+// plural は値の複数形カテゴリを返す
+func (l *Locale) plural(value string) PluralCategory {
+	if l.Plural == nil {
+		return PluralOther
+	}
+	return l.Plural(value)
+}
+
+// join は要素をリストパターンで連結する
+func (p ListPattern) join(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return listReplace(p.Pair, items[0], items[1])
+	}
+	s := listReplace(p.End, items[len(items)-2], items[len(items)-1])
+	for i := len(items) - 3; i > 0; i-- {
+		s = listReplace(p.Middle, items[i], s)
+	}
+	return listReplace(p.Start, items[0], s)
+}
+
+// listReplace はリストパターンに要素を埋め込む
+func listReplace(pattern, a, b string) string {
+	return strings.NewReplacer("{0}", a, "{1}", b).Replace(pattern)
+}
+
+// pluralOperands は値 (ex. 1.5) の整数部 (i) と、小数部の桁数 (v) を返す
+func pluralOperands(value string) (uint64, int) {
+	integer, fraction, _ := strings.Cut(value, ".")
+	i, _ := strconv.ParseUint(integer, 10, 64)
+	return i, len(fraction)
+}
+
+// pluralOneOther は整数の1を one とする複数形ルール (en, de など)
+func pluralOneOther(value string) PluralCategory {
+	if i, v := pluralOperands(value); i == 1 && v == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralFrench はフランス語の複数形ルール
+// 整数部が0か1の場合は one 、100万の倍数の場合は many とする
+func pluralFrench(value string) PluralCategory {
+	i, v := pluralOperands(value)
+	switch {
+	case i == 0 || i == 1:
+		return PluralOne
+	case v == 0 && i%1000000 == 0:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
@@ -0,0 +1,277 @@
+package iso8601duration
+
+// CLDR の単位パターン (unitLength long/short/narrow) 、リストパターン (standard/unit/unit-narrow) 、複数形ルールを元にしたデータ
+
+// oneOther は one と other のパターンを返す
+func oneOther(one, other string) UnitPattern {
+	return UnitPattern{PluralOne: one, PluralOther: other}
+}
+
+// otherOnly は全てのカテゴリで同じパターンを返す
+func otherOnly(other string) UnitPattern {
+	return UnitPattern{PluralOther: other}
+}
+
+// simpleList は区切りのみのリストパターンを返す
+func simpleList(separator string) ListPattern {
+	p := "{0}" + separator + "{1}"
+	return ListPattern{Start: p, Middle: p, End: p, Pair: p}
+}
+
+// andList は最後の要素のみ接続詞で連結するリストパターンを返す
+func andList(separator, conjunction string) ListPattern {
+	p := "{0}" + separator + "{1}"
+	c := "{0}" + conjunction + "{1}"
+	return ListPattern{Start: p, Middle: p, End: c, Pair: c}
+}
+
+var englishAbbreviations = map[Unit]UnitPattern{
+	UnitYear:   otherOnly("{0}y"),
+	UnitMonth:  otherOnly("{0}mo"),
+	UnitWeek:   otherOnly("{0}w"),
+	UnitDay:    otherOnly("{0}d"),
+	UnitHour:   otherOnly("{0}h"),
+	UnitMinute: otherOnly("{0}m"),
+	UnitSecond: otherOnly("{0}s"),
+}
+
+var localeEnglish = Locale{
+	Units: [3]map[Unit]UnitPattern{
+		{
+			UnitYear:   oneOther("{0} year", "{0} years"),
+			UnitMonth:  oneOther("{0} month", "{0} months"),
+			UnitWeek:   oneOther("{0} week", "{0} weeks"),
+			UnitDay:    oneOther("{0} day", "{0} days"),
+			UnitHour:   oneOther("{0} hour", "{0} hours"),
+			UnitMinute: oneOther("{0} minute", "{0} minutes"),
+			UnitSecond: oneOther("{0} second", "{0} seconds"),
+		},
+		englishAbbreviations,
+		englishAbbreviations,
+	},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   andList(", ", " and "),
+		ListStyleComma: simpleList(", "),
+		ListStyleSpace: simpleList(" "),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleAnd, ListStyleSpace, ListStyleNone},
+	Plural:           pluralOneOther,
+	DecimalSeparator: ".",
+	Negative:         [3]string{"minus {0}", "-{0}", "-{0}"},
+	Future:           "in {0}",
+	Past:             "{0} ago",
+	Now:              "now",
+	ParseUnits: map[string]Unit{
+		"yr": UnitYear, "yrs": UnitYear,
+		"mon": UnitMonth, "mons": UnitMonth, "mos": UnitMonth,
+		"wk": UnitWeek, "wks": UnitWeek,
+		"hr": UnitHour, "hrs": UnitHour,
+		"min": UnitMinute, "mins": UnitMinute,
+		"sec": UnitSecond, "secs": UnitSecond,
+	},
+	ParseIgnore: []string{"&", "+"},
+}
+
+var japaneseUnits = map[Unit]UnitPattern{
+	UnitYear:   otherOnly("{0}年"),
+	UnitMonth:  otherOnly("{0}か月"),
+	UnitWeek:   otherOnly("{0}週間"),
+	UnitDay:    otherOnly("{0}日"),
+	UnitHour:   otherOnly("{0}時間"),
+	UnitMinute: otherOnly("{0}分"),
+	UnitSecond: otherOnly("{0}秒"),
+}
+
+var localeJapanese = Locale{
+	Units: [3]map[Unit]UnitPattern{japaneseUnits, japaneseUnits, japaneseUnits},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   simpleList("、"),
+		ListStyleComma: simpleList("、"),
+		ListStyleSpace: simpleList(" "),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleNone, ListStyleNone, ListStyleNone},
+	DecimalSeparator: ".",
+	Negative:         [3]string{"マイナス{0}", "-{0}", "-{0}"},
+	Future:           "{0}後",
+	Past:             "{0}前",
+	Now:              "今",
+	ParseUnits: map[string]Unit{
+		"ヶ月": UnitMonth, "カ月": UnitMonth, "ヵ月": UnitMonth, "ケ月": UnitMonth, "箇月": UnitMonth,
+		"週": UnitWeek,
+	},
+	ParseIgnore: []string{"と"},
+}
+
+var localeGerman = Locale{
+	Units: [3]map[Unit]UnitPattern{
+		{
+			UnitYear:   oneOther("{0} Jahr", "{0} Jahre"),
+			UnitMonth:  oneOther("{0} Monat", "{0} Monate"),
+			UnitWeek:   oneOther("{0} Woche", "{0} Wochen"),
+			UnitDay:    oneOther("{0} Tag", "{0} Tage"),
+			UnitHour:   oneOther("{0} Stunde", "{0} Stunden"),
+			UnitMinute: oneOther("{0} Minute", "{0} Minuten"),
+			UnitSecond: oneOther("{0} Sekunde", "{0} Sekunden"),
+		},
+		{
+			UnitYear:   otherOnly("{0} J."),
+			UnitMonth:  otherOnly("{0} Mon."),
+			UnitWeek:   otherOnly("{0} Wo."),
+			UnitDay:    otherOnly("{0} Tg."),
+			UnitHour:   otherOnly("{0} Std."),
+			UnitMinute: otherOnly("{0} Min."),
+			UnitSecond: otherOnly("{0} Sek."),
+		},
+		{
+			UnitYear:   otherOnly("{0} J"),
+			UnitMonth:  otherOnly("{0} M"),
+			UnitWeek:   otherOnly("{0} W"),
+			UnitDay:    otherOnly("{0} T"),
+			UnitHour:   otherOnly("{0} Std."),
+			UnitMinute: otherOnly("{0} Min."),
+			UnitSecond: otherOnly("{0} Sek."),
+		},
+	},
+	// 前置詞 in, vor の後は与格となる
+	RelativeUnits: [3]map[Unit]UnitPattern{
+		{
+			UnitYear:   oneOther("{0} Jahr", "{0} Jahren"),
+			UnitMonth:  oneOther("{0} Monat", "{0} Monaten"),
+			UnitWeek:   oneOther("{0} Woche", "{0} Wochen"),
+			UnitDay:    oneOther("{0} Tag", "{0} Tagen"),
+			UnitHour:   oneOther("{0} Stunde", "{0} Stunden"),
+			UnitMinute: oneOther("{0} Minute", "{0} Minuten"),
+			UnitSecond: oneOther("{0} Sekunde", "{0} Sekunden"),
+		},
+	},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   andList(", ", " und "),
+		ListStyleComma: simpleList(", "),
+		ListStyleSpace: simpleList(" "),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleAnd, ListStyleComma, ListStyleSpace},
+	Plural:           pluralOneOther,
+	DecimalSeparator: ",",
+	Negative:         [3]string{"minus {0}", "-{0}", "-{0}"},
+	Future:           "in {0}",
+	Past:             "vor {0}",
+	Now:              "jetzt",
+}
+
+var localeFrench = Locale{
+	Units: [3]map[Unit]UnitPattern{
+		{
+			UnitYear:   oneOther("{0} an", "{0} ans"),
+			UnitMonth:  otherOnly("{0} mois"),
+			UnitWeek:   oneOther("{0} semaine", "{0} semaines"),
+			UnitDay:    oneOther("{0} jour", "{0} jours"),
+			UnitHour:   oneOther("{0} heure", "{0} heures"),
+			UnitMinute: oneOther("{0} minute", "{0} minutes"),
+			UnitSecond: oneOther("{0} seconde", "{0} secondes"),
+		},
+		{
+			UnitYear:   oneOther("{0} an", "{0} ans"),
+			UnitMonth:  otherOnly("{0} m."),
+			UnitWeek:   otherOnly("{0} sem."),
+			UnitDay:    otherOnly("{0} j"),
+			UnitHour:   otherOnly("{0} h"),
+			UnitMinute: otherOnly("{0} min"),
+			UnitSecond: otherOnly("{0} s"),
+		},
+		{
+			UnitYear:   otherOnly("{0}a"),
+			UnitMonth:  otherOnly("{0}m."),
+			UnitWeek:   otherOnly("{0}sem."),
+			UnitDay:    otherOnly("{0}j"),
+			UnitHour:   otherOnly("{0}h"),
+			UnitMinute: otherOnly("{0}min"),
+			UnitSecond: otherOnly("{0}s"),
+		},
+	},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   andList(", ", " et "),
+		ListStyleComma: simpleList(", "),
+		ListStyleSpace: simpleList(" "),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleAnd, ListStyleComma, ListStyleSpace},
+	Plural:           pluralFrench,
+	DecimalSeparator: ",",
+	Negative:         [3]string{"moins {0}", "-{0}", "-{0}"},
+	Future:           "dans {0}",
+	Past:             "il y a {0}",
+	Now:              "maintenant",
+}
+
+var localeChinese = Locale{
+	Units: [3]map[Unit]UnitPattern{
+		{
+			UnitYear:   otherOnly("{0}年"),
+			UnitMonth:  otherOnly("{0}个月"),
+			UnitWeek:   otherOnly("{0}周"),
+			UnitDay:    otherOnly("{0}天"),
+			UnitHour:   otherOnly("{0}小时"),
+			UnitMinute: otherOnly("{0}分钟"),
+			UnitSecond: otherOnly("{0}秒钟"),
+		},
+		{
+			UnitYear:   otherOnly("{0}年"),
+			UnitMonth:  otherOnly("{0}个月"),
+			UnitWeek:   otherOnly("{0}周"),
+			UnitDay:    otherOnly("{0}天"),
+			UnitHour:   otherOnly("{0}小时"),
+			UnitMinute: otherOnly("{0}分钟"),
+			UnitSecond: otherOnly("{0}秒"),
+		},
+		{
+			UnitYear:   otherOnly("{0}年"),
+			UnitMonth:  otherOnly("{0}个月"),
+			UnitWeek:   otherOnly("{0}周"),
+			UnitDay:    otherOnly("{0}天"),
+			UnitHour:   otherOnly("{0}小时"),
+			UnitMinute: otherOnly("{0}分钟"),
+			UnitSecond: otherOnly("{0}秒"),
+		},
+	},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   andList("、", "和"),
+		ListStyleComma: simpleList("、"),
+		ListStyleSpace: simpleList(""),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleSpace, ListStyleSpace, ListStyleNone},
+	DecimalSeparator: ".",
+	Negative:         [3]string{"负{0}", "-{0}", "-{0}"},
+	Future:           "{0}后",
+	Past:             "{0}前",
+	Now:              "现在",
+}
+
+var koreanUnits = map[Unit]UnitPattern{
+	UnitYear:   otherOnly("{0}년"),
+	UnitMonth:  otherOnly("{0}개월"),
+	UnitWeek:   otherOnly("{0}주"),
+	UnitDay:    otherOnly("{0}일"),
+	UnitHour:   otherOnly("{0}시간"),
+	UnitMinute: otherOnly("{0}분"),
+	UnitSecond: otherOnly("{0}초"),
+}
+
+var localeKorean = Locale{
+	Units: [3]map[Unit]UnitPattern{koreanUnits, koreanUnits, koreanUnits},
+	Lists: map[ListStyle]ListPattern{
+		ListStyleAnd:   andList(", ", " 및 "),
+		ListStyleComma: simpleList(", "),
+		ListStyleSpace: simpleList(" "),
+		ListStyleNone:  simpleList(""),
+	},
+	DefaultLists:     [3]ListStyle{ListStyleSpace, ListStyleSpace, ListStyleNone},
+	DecimalSeparator: ".",
+	Negative:         [3]string{"마이너스 {0}", "-{0}", "-{0}"},
+	Future:           "{0} 후",
+	Past:             "{0} 전",
+	Now:              "지금",
+}
@@ -0,0 +1,150 @@
+package iso8601duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeLocale(t *testing.T) {
+	d := Duration{Years: 1, Months: 2, Days: 3}
+	tests := []struct {
+		tag  string
+		d    Duration
+		opts HumanizeOptions
+		want string
+	}{
+		{tag: "en", d: d, opts: HumanizeOptions{}, want: "1 year, 2 months and 3 days"},
+		{tag: "de", d: d, opts: HumanizeOptions{}, want: "1 Jahr, 2 Monate und 3 Tage"},
+		{tag: "de", d: d, opts: HumanizeOptions{Style: HumanizeShort}, want: "1 J., 2 Mon., 3 Tg."},
+		{tag: "de", d: Duration{Seconds: 1, Nanoseconds: 500000000}, opts: HumanizeOptions{}, want: "1,5 Sekunden"},
+		{tag: "de", d: Duration{Days: 3}, opts: HumanizeOptions{Relative: true}, want: "in 3 Tagen"},
+		{tag: "de", d: Duration{Negative: true, Years: 1, Months: 2}, opts: HumanizeOptions{Relative: true}, want: "vor 1 Jahr und 2 Monaten"},
+		{tag: "de", d: Duration{}, opts: HumanizeOptions{Relative: true}, want: "jetzt"},
+		{tag: "de-AT", d: Duration{Hours: 1}, opts: HumanizeOptions{}, want: "1 Stunde"},
+		{tag: "fr", d: d, opts: HumanizeOptions{}, want: "1 an, 2 mois et 3 jours"},
+		{tag: "fr", d: Duration{Seconds: 1, Nanoseconds: 500000000}, opts: HumanizeOptions{}, want: "1,5 seconde"},
+		{tag: "fr", d: Duration{Days: 0, Hours: 2}, opts: HumanizeOptions{}, want: "2 heures"},
+		{tag: "fr", d: Duration{Negative: true, Weeks: 2}, opts: HumanizeOptions{Relative: true}, want: "il y a 2 semaines"},
+		{tag: "fr", d: Duration{Days: 1, Hours: 1}, opts: HumanizeOptions{Style: HumanizeNarrow}, want: "1j 1h"},
+		{tag: "zh", d: d, opts: HumanizeOptions{}, want: "1年2个月3天"},
+		{tag: "zh-Hans-CN", d: Duration{Hours: 1, Seconds: 5}, opts: HumanizeOptions{List: ListStyleAnd}, want: "1小时和5秒钟"},
+		{tag: "zh", d: Duration{Days: 3}, opts: HumanizeOptions{Relative: true}, want: "3天后"},
+		{tag: "ko", d: d, opts: HumanizeOptions{}, want: "1년 2개월 3일"},
+		{tag: "ko", d: Duration{Negative: true, Days: 3}, opts: HumanizeOptions{Relative: true}, want: "3일 전"},
+		{tag: "ko_KR", d: Duration{Hours: 1, Minutes: 30}, opts: HumanizeOptions{Style: HumanizeNarrow}, want: "1시간30분"},
+		{tag: "ja", d: d, opts: HumanizeOptions{}, want: "1年2か月3日"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.want, func(t *testing.T) {
+			actual, err := HumanizeLocale(tt.d, tt.tag, tt.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	_, err := HumanizeLocale(d, "xx", HumanizeOptions{})
+	assert.ErrorIs(t, err, ErrUnknownLocale)
+}
+
+func TestRegisterLocale(t *testing.T) {
+	units := map[Unit]UnitPattern{
+		UnitYear:   {PluralOne: "{0} año", PluralOther: "{0} años"},
+		UnitMonth:  {PluralOne: "{0} mes", PluralOther: "{0} meses"},
+		UnitWeek:   {PluralOne: "{0} semana", PluralOther: "{0} semanas"},
+		UnitDay:    {PluralOne: "{0} día", PluralOther: "{0} días"},
+		UnitHour:   {PluralOne: "{0} hora", PluralOther: "{0} horas"},
+		UnitMinute: {PluralOne: "{0} minuto", PluralOther: "{0} minutos"},
+		UnitSecond: {PluralOne: "{0} segundo", PluralOther: "{0} segundos"},
+	}
+	RegisterLocale("es", &Locale{
+		Units: [3]map[Unit]UnitPattern{units, units, units},
+		Lists: map[ListStyle]ListPattern{
+			ListStyleAnd: {Start: "{0}, {1}", Middle: "{0}, {1}", End: "{0} y {1}", Pair: "{0} y {1}"},
+		},
+		DefaultLists: [3]ListStyle{ListStyleAnd, ListStyleAnd, ListStyleAnd},
+		Plural: func(value string) PluralCategory {
+			if value == "1" {
+				return PluralOne
+			}
+			return PluralOther
+		},
+		DecimalSeparator: ",",
+		Negative:         [3]string{"-{0}", "-{0}", "-{0}"},
+		Future:           "dentro de {0}",
+		Past:             "hace {0}",
+		Now:              "ahora",
+	})
+	l, ok := LookupLocale("es-MX")
+	assert.True(t, ok)
+	assert.Equal(t, "1 año, 2 meses, 1 día y 1,5 segundos", l.Humanize(Duration{Years: 1, Months: 2, Days: 1, Seconds: 1, Nanoseconds: 500000000}, HumanizeOptions{}))
+	assert.Equal(t, "hace 3 días", l.Humanize(Duration{Negative: true, Days: 3}, HumanizeOptions{Relative: true}))
+}
+
+func TestLocaleCopy(t *testing.T) {
+	// LookupLocale の返り値を変更しても、登録済みのデータには影響しない
+	l, ok := LookupLocale("en")
+	assert.True(t, ok)
+	l.Now = "changed"
+	l.Units[HumanizeLong][UnitDay][PluralOther] = "{0} changed"
+	assert.Equal(t, "now", HumanizeOptions{Relative: true}.Humanize(Duration{}))
+	assert.Equal(t, "3 days", Humanize(Duration{Days: 3}, HumanizeLong))
+
+	// 登録後に元のデータを変更しても影響しない
+	custom, ok := LookupLocale("en")
+	assert.True(t, ok)
+	custom.Now = "right now"
+	RegisterLocale("en-x-test", custom)
+	custom.Now = "changed"
+	actual, err := HumanizeLocale(Duration{}, "en-x-test", HumanizeOptions{Relative: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "right now", actual)
+}
+
+func TestRegisterLocaleEnglish(t *testing.T) {
+	// Humanize, ParseHuman は en に登録されたデータを使用する
+	t.Cleanup(func() {
+		RegisterLocale("en", &localeEnglish)
+	})
+	l, ok := LookupLocale("en")
+	assert.True(t, ok)
+	l.Now = "right now"
+	l.ParseUnits = map[string]Unit{"sennights": UnitWeek}
+	RegisterLocale("en", l)
+
+	assert.Equal(t, "right now", HumanizeOptions{Relative: true}.Humanize(Duration{}))
+	actual, err := ParseHuman("2 sennights")
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Weeks: 2}, *actual)
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		value   string
+		english PluralCategory
+		french  PluralCategory
+	}{
+		{value: "0", english: PluralOther, french: PluralOne},
+		{value: "1", english: PluralOne, french: PluralOne},
+		{value: "1.0", english: PluralOther, french: PluralOne},
+		{value: "1.5", english: PluralOther, french: PluralOne},
+		{value: "2", english: PluralOther, french: PluralOther},
+		{value: "1000000", english: PluralOther, french: PluralMany},
+		{value: "1000000.5", english: PluralOther, french: PluralOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.english, pluralOneOther(tt.value))
+			assert.Equal(t, tt.french, pluralFrench(tt.value))
+		})
+	}
+}
+
+func TestListPattern(t *testing.T) {
+	p := ListPattern{Start: "{0}; {1}", Middle: "{0}, {1}", End: "{0} & {1}", Pair: "{0} + {1}"}
+	assert.Equal(t, "", p.join(nil))
+	assert.Equal(t, "a", p.join([]string{"a"}))
+	assert.Equal(t, "a + b", p.join([]string{"a", "b"}))
+	assert.Equal(t, "a; b & c", p.join([]string{"a", "b", "c"}))
+	assert.Equal(t, "a; b, c, d & e", p.join([]string{"a", "b", "c", "d", "e"}))
+}
@@ -0,0 +1,271 @@
+package iso8601duration
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// UnknownTokensError は読みやすい書式のパースで、解釈出来なかった語を持つエラー
+type UnknownTokensError struct {
+	// Tokens 解釈出来なかった語 (小文字に変換した値)
+	Tokens []string
+}
+
+func (e *UnknownTokensError) Error() string {
+	return fmt.Sprintf("%s: unknown tokens %q", ErrBadFormat, e.Tokens)
+}
+
+// Unwrap は ErrBadFormat を返す
+func (e *UnknownTokensError) Unwrap() error {
+	return ErrBadFormat
+}
+
+// humanVocabulary は読みやすい書式のパースに使用する語彙
+type humanVocabulary struct {
+	units  map[string]Unit
+	ignore map[string]bool
+	// words 全ての語 (前方一致のため、長い順に並べる)
+	words []string
+}
+
+// ParseHuman は英語の読みやすい書式 (ex. 1 week and 2 days, 90 min, 1.5h, 3d4h) をパースし、 Duration を返す
+// 解釈出来ない語がある場合は *UnknownTokensError を返す
+// 言語タグ en に登録されたデータ (RegisterLocale) を使用する
+func ParseHuman(s string) (*Duration, error) {
+	return englishLocale().ParseHuman(s)
+}
+
+// ParseHumanLocale は言語タグに対応する言語の読みやすい書式をパースし、 Duration を返す
+func ParseHumanLocale(s string, tag string) (*Duration, error) {
+	l, ok := lookupLocale(tag)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLocale, tag)
+	}
+	return l.ParseHuman(s)
+}
+
+// ParseHuman は読みやすい書式をパースし、 Duration を返す
+// 単位の表記は Units, RelativeUnits のパターンと ParseUnits 、無視する語は Lists の接続詞と ParseIgnore から求める
+// Negative, Past のパターンに一致する場合はマイナス期間とする (ex. -3d, 3 days ago)
+func (l *Locale) ParseHuman(s string) (*Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(strings.Map(toHalfWidth, s)))
+	negative := false
+	if rest, ok := trimPatterns(s, append([]string{l.Past}, l.Negative[:]...)); ok {
+		s, negative = rest, true
+	} else if rest, ok := trimPatterns(s, []string{l.Future}); ok {
+		s = rest
+	}
+
+	vocabulary := l.vocabulary()
+	values := make(map[Unit]decimal.Decimal)
+	var unknown []string
+	var number string
+	found := false
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case isHumanSeparator(r):
+			s = s[size:]
+		case isDigit(r):
+			if number != "" {
+				unknown = append(unknown, number)
+			}
+			number, s = scanNumber(s)
+		default:
+			// 単位、または無視する語を探す
+			word := s[:strings.IndexFunc(s+"0", func(r rune) bool { return isDigit(r) || isHumanSeparator(r) })]
+			w, ok := vocabulary.match(word)
+			if !ok {
+				// 数値は、解釈出来ない単位の値とみなす
+				unknown = append(unknown, word)
+				s = s[len(word):]
+				number = ""
+				continue
+			}
+			s = s[len(w):]
+			if vocabulary.ignore[w] {
+				continue
+			}
+			if number == "" {
+				unknown = append(unknown, w)
+				continue
+			}
+			v, err := decimal.NewFromString(strings.ReplaceAll(number, ",", "."))
+			if err != nil {
+				unknown = append(unknown, number)
+			} else {
+				unit := vocabulary.units[w]
+				values[unit] = values[unit].Add(v)
+				found = true
+			}
+			number = ""
+		}
+	}
+	if number != "" {
+		unknown = append(unknown, number)
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownTokensError{Tokens: unknown}
+	}
+	if !found {
+		return nil, ErrBadFormat
+	}
+
+	// ISO-8601 Duration書式に変換し、小数の繰り下げは ParseString に任せる
+	// 週は小数を持てないため、小数の場合は日に換算する
+	weeks, days := values[UnitWeek], values[UnitDay]
+	if !weeks.IsInteger() {
+		days = days.Add(weeks.Mul(daysPerWeek))
+		weeks = decimal.Zero
+	}
+	var builder strings.Builder
+	if negative {
+		builder.WriteByte('-')
+	}
+	builder.WriteByte('P')
+	write := func(v decimal.Decimal, designator byte) {
+		if !v.IsZero() {
+			builder.WriteString(v.String())
+			builder.WriteByte(designator)
+		}
+	}
+	write(values[UnitYear], 'Y')
+	write(values[UnitMonth], 'M')
+	write(weeks, 'W')
+	write(days, 'D')
+	if hours, minutes, seconds := values[UnitHour], values[UnitMinute], values[UnitSecond]; !hours.IsZero() || !minutes.IsZero() || !seconds.IsZero() {
+		builder.WriteByte('T')
+		write(hours, 'H')
+		write(minutes, 'M')
+		write(seconds, 'S')
+	}
+	iso := builder.String()
+	if strings.HasSuffix(iso, "P") {
+		iso += "T0S"
+	}
+	return ParseString(iso)
+}
+
+// vocabulary は読みやすい書式のパースに使用する語彙を返す
+func (l *Locale) vocabulary() humanVocabulary {
+	v := humanVocabulary{units: make(map[string]Unit), ignore: make(map[string]bool)}
+	for _, units := range append(l.Units[:], l.RelativeUnits[:]...) {
+		for unit, pattern := range units {
+			for _, p := range pattern {
+				if w := patternWord(p, "{0}"); w != "" {
+					v.units[w] = unit
+				}
+			}
+		}
+	}
+	// 空の語は全ての語に前方一致するため除く
+	for w, unit := range l.ParseUnits {
+		if w := strings.ToLower(strings.TrimFunc(w, isHumanSeparator)); w != "" {
+			v.units[w] = unit
+		}
+	}
+	for _, list := range l.Lists {
+		for _, p := range []string{list.Start, list.Middle, list.End, list.Pair} {
+			if w := patternWord(strings.Replace(p, "{0}", "", 1), "{1}"); w != "" {
+				v.ignore[w] = true
+			}
+		}
+	}
+	for _, w := range l.ParseIgnore {
+		if w := strings.ToLower(strings.TrimFunc(w, isHumanSeparator)); w != "" {
+			v.ignore[w] = true
+		}
+	}
+
+	for w := range v.units {
+		v.words = append(v.words, w)
+	}
+	for w := range v.ignore {
+		if _, ok := v.units[w]; !ok {
+			v.words = append(v.words, w)
+		}
+	}
+	slices.SortFunc(v.words, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	return v
+}
+
+// match は word の先頭に一致する語を返す
+// 空白で語を区切らない文字 (漢字、仮名、ハングル) で始まる場合は前方一致 (ex. 時間と) 、
+// それ以外の場合は語全体の一致とする (ex. monday は mon と day に分けない)
+func (v humanVocabulary) match(word string) (string, bool) {
+	if r, _ := utf8.DecodeRuneInString(word); !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		_, ok := v.units[word]
+		return word, ok || v.ignore[word]
+	}
+	i := slices.IndexFunc(v.words, func(w string) bool { return strings.HasPrefix(word, w) })
+	if i < 0 {
+		return "", false
+	}
+	return v.words[i], true
+}
+
+// patternWord はパターンから placeholder と区切り文字を除いた語を、小文字で返す
+func patternWord(pattern, placeholder string) string {
+	return strings.ToLower(strings.TrimFunc(strings.Replace(pattern, placeholder, "", 1), isHumanSeparator))
+}
+
+// trimPatterns は s がパターン (ex. {0} ago) のいずれかに一致する場合、 {0} 以外の部分を除いて返す
+func trimPatterns(s string, patterns []string) (string, bool) {
+	for _, p := range patterns {
+		before, after, ok := strings.Cut(strings.ToLower(p), "{0}")
+		if !ok || (before == "" && after == "") {
+			continue
+		}
+		before, after = strings.TrimSpace(before), strings.TrimSpace(after)
+		if strings.HasPrefix(s, before) && strings.HasSuffix(s, after) && len(s) > len(before)+len(after) {
+			return strings.TrimSpace(s[len(before) : len(s)-len(after)]), true
+		}
+	}
+	return s, false
+}
+
+// scanNumber は s の先頭の数値 (ex. 1, 1.5, 1,5) と、残りの文字列を返す
+func scanNumber(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	if i+1 < len(s) && (s[i] == '.' || s[i] == ',') && isDigit(rune(s[i+1])) {
+		i++
+		for i < len(s) && isDigit(rune(s[i])) {
+			i++
+		}
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// isHumanSeparator は語の区切りとなる文字かを返す
+func isHumanSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ',' || r == ';' || r == '、' || r == '，'
+}
+
+// toHalfWidth は全角の数字と小数点を半角に変換する
+func toHalfWidth(r rune) rune {
+	switch {
+	case '０' <= r && r <= '９':
+		return r - '０' + '0'
+	case r == '．':
+		return '.'
+	default:
+		return r
+	}
+}
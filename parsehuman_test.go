@@ -0,0 +1,155 @@
+package iso8601duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHuman(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Duration
+	}{
+		{s: "1 week and 2 days", want: Duration{Weeks: 1, Days: 2}},
+		{s: "90 min", want: Duration{Minutes: 90}},
+		{s: "1.5h", want: Duration{Hours: 1, Minutes: 30}},
+		{s: "3d4h", want: Duration{Days: 3, Hours: 4}},
+		{s: "1 year, 2 months and 3 days", want: Duration{Years: 1, Months: 2, Days: 3}},
+		{s: "1y 2mo 3d", want: Duration{Years: 1, Months: 2, Days: 3}},
+		{s: "2 Hours 5 Mins 30 secs", want: Duration{Hours: 2, Minutes: 5, Seconds: 30}},
+		{s: "1.5 weeks", want: Duration{Days: 10, Hours: 12}},
+		{s: "1,5 days", want: Duration{Days: 1, Hours: 12}},
+		{s: "0.25s", want: Duration{Nanoseconds: 250000000}},
+		{s: "1h + 1h", want: Duration{Hours: 2}},
+		{s: "0 seconds", want: Duration{}},
+		{s: "-3d", want: Duration{Negative: true, Days: 3}},
+		{s: "minus 1 day", want: Duration{Negative: true, Days: 1}},
+		{s: "3 days ago", want: Duration{Negative: true, Days: 3}},
+		{s: "in 3 days", want: Duration{Days: 3}},
+		{s: "  1 hr  ", want: Duration{Hours: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			actual, err := ParseHuman(tt.s)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+}
+
+func TestParseHumanError(t *testing.T) {
+	tests := []struct {
+		s      string
+		tokens []string
+	}{
+		{s: "1 fortnight", tokens: []string{"fortnight"}},
+		{s: "1h 30", tokens: []string{"30"}},
+		{s: "hours", tokens: []string{"hours"}},
+		{s: "1 2 days", tokens: []string{"1"}},
+		{s: "3 dayz and 4 blah", tokens: []string{"dayz", "blah"}},
+		{s: "300ms", tokens: []string{"ms"}},
+		// 語全体で一致させる (mon と day に分けない)
+		{s: "1 monday", tokens: []string{"monday"}},
+		{s: "2 hoursand", tokens: []string{"hoursand"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := ParseHuman(tt.s)
+			assert.ErrorIs(t, err, ErrBadFormat)
+			var unknown *UnknownTokensError
+			assert.ErrorAs(t, err, &unknown)
+			assert.Equal(t, tt.tokens, unknown.Tokens)
+		})
+	}
+
+	_, err := ParseHuman("1 fortnight")
+	assert.EqualError(t, err, `bad format string: unknown tokens ["fortnight"]`)
+
+	// 単位がない
+	_, err = ParseHuman("")
+	assert.ErrorIs(t, err, ErrBadFormat)
+	_, err = ParseHuman("and")
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	// 月は小数を持てない
+	_, err = ParseHuman("1.5 months")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestParseHumanLocale(t *testing.T) {
+	tests := []struct {
+		tag  string
+		s    string
+		want Duration
+	}{
+		{tag: "ja", s: "2時間30分", want: Duration{Hours: 2, Minutes: 30}},
+		{tag: "ja", s: "1年2ヶ月3日", want: Duration{Years: 1, Months: 2, Days: 3}},
+		{tag: "ja", s: "２週間と１日", want: Duration{Weeks: 2, Days: 1}},
+		{tag: "ja", s: "1.5秒", want: Duration{Seconds: 1, Nanoseconds: 500000000}},
+		{tag: "ja", s: "3日前", want: Duration{Negative: true, Days: 3}},
+		{tag: "ja", s: "3日後", want: Duration{Days: 3}},
+		{tag: "de", s: "1 Jahr, 2 Monate und 3 Tage", want: Duration{Years: 1, Months: 2, Days: 3}},
+		{tag: "de", s: "vor 3 Tagen", want: Duration{Negative: true, Days: 3}},
+		{tag: "de", s: "2 Std. 30 Min.", want: Duration{Hours: 2, Minutes: 30}},
+		{tag: "fr", s: "il y a 2 semaines", want: Duration{Negative: true, Weeks: 2}},
+		{tag: "fr", s: "1 an et 1,5 jour", want: Duration{Years: 1, Days: 1, Hours: 12}},
+		{tag: "zh", s: "1小时和5秒钟", want: Duration{Hours: 1, Seconds: 5}},
+		{tag: "ko", s: "1시간 30분 전", want: Duration{Negative: true, Hours: 1, Minutes: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.s, func(t *testing.T) {
+			actual, err := ParseHumanLocale(tt.s, tt.tag)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+
+	_, err := ParseHumanLocale("2時間30秒間", "ja")
+	var unknown *UnknownTokensError
+	assert.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"間"}, unknown.Tokens)
+
+	_, err = ParseHumanLocale("1h", "xx")
+	assert.ErrorIs(t, err, ErrUnknownLocale)
+}
+
+func TestParseHumanCustomLocale(t *testing.T) {
+	// 空の語は無視する (全ての語に前方一致するため)
+	l := &Locale{
+		Units: [3]map[Unit]UnitPattern{
+			{UnitDay: {PluralOther: "{0} days"}},
+		},
+		ParseUnits:  map[string]Unit{"": UnitHour, "D": UnitDay},
+		ParseIgnore: []string{"", " ", "plus"},
+	}
+	actual, err := l.ParseHuman("1 days plus 2d")
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Days: 3}, *actual)
+
+	_, err = l.ParseHuman("1 hour")
+	var unknown *UnknownTokensError
+	assert.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"hour"}, unknown.Tokens)
+}
+
+func TestParseHumanRoundTrip(t *testing.T) {
+	// Humanize の出力をパース出来る
+	d := Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 500000000}
+	for _, tag := range []string{"en", "ja", "de", "fr", "zh", "ko"} {
+		l, ok := LookupLocale(tag)
+		assert.True(t, ok)
+		for _, style := range []HumanizeStyle{HumanizeLong, HumanizeShort, HumanizeNarrow} {
+			for _, relative := range []bool{false, true} {
+				for _, negative := range []bool{false, true} {
+					d.Negative = negative
+					s := l.Humanize(d, HumanizeOptions{Style: style, Relative: relative})
+					actual, err := l.ParseHuman(s)
+					if assert.Nil(t, err, "%s %s", tag, s) {
+						assert.Equal(t, d, *actual, "%s %s", tag, s)
+					}
+				}
+			}
+		}
+	}
+}
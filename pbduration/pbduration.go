@@ -0,0 +1,38 @@
+// Package pbduration は iso8601duration.Duration と google.protobuf.Duration を相互に変換する
+package pbduration
+
+import (
+	"time"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ToProto は google.protobuf.Duration に変換する
+// 1日は24時間として換算する
+// 年月を含む場合は iso8601duration.ErrCalendarComponent を返すため、 ToProtoAt を使用する
+func ToProto(d iso8601duration.Duration) (*durationpb.Duration, error) {
+	seconds, nanos, err := d.ToSecondsNanos()
+	if err != nil {
+		return nil, err
+	}
+	return &durationpb.Duration{Seconds: seconds, Nanos: nanos}, nil
+}
+
+// ToProtoAt は基準日時 ref から暦に従って換算し、 google.protobuf.Duration に変換する
+func ToProtoAt(d iso8601duration.Duration, ref time.Time) (*durationpb.Duration, error) {
+	seconds, nanos, err := d.ToSecondsNanosAt(ref)
+	if err != nil {
+		return nil, err
+	}
+	return &durationpb.Duration{Seconds: seconds, Nanos: nanos}, nil
+}
+
+// FromProto は google.protobuf.Duration から時刻部 (時分秒) のみの Duration を返す
+// nil の場合は iso8601duration.ErrBadFormat を返す
+func FromProto(p *durationpb.Duration) (iso8601duration.Duration, error) {
+	if p == nil {
+		return iso8601duration.Duration{}, iso8601duration.ErrBadFormat
+	}
+	return iso8601duration.FromSecondsNanos(p.GetSeconds(), p.GetNanos())
+}
@@ -0,0 +1,51 @@
+package pbduration
+
+import (
+	"testing"
+	"time"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestToProto(t *testing.T) {
+	d, err := iso8601duration.ParseString("P1DT1H30M0.5S")
+	assert.Nil(t, err)
+
+	actual, err := ToProto(*d)
+	assert.Nil(t, err)
+	assert.Nil(t, actual.CheckValid())
+	assert.Equal(t, 25*time.Hour+30*time.Minute+500*time.Millisecond, actual.AsDuration())
+
+	// 年月
+	_, err = ToProto(iso8601duration.Duration{Months: 1})
+	assert.ErrorIs(t, err, iso8601duration.ErrCalendarComponent)
+
+	// 基準日時
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	actual, err = ToProtoAt(iso8601duration.Duration{Negative: true, Months: 1}, ref)
+	assert.Nil(t, err)
+	assert.Equal(t, -31*24*time.Hour, actual.AsDuration())
+}
+
+func TestFromProto(t *testing.T) {
+	actual, err := FromProto(durationpb.New(-90*time.Minute - time.Nanosecond))
+	assert.Nil(t, err)
+	assert.Equal(t, "-PT1H30M0.000000001S", actual.String())
+
+	// シリアライズしたメッセージ
+	b, err := proto.Marshal(durationpb.New(30 * time.Second))
+	assert.Nil(t, err)
+	var p durationpb.Duration
+	assert.Nil(t, proto.Unmarshal(b, &p))
+	actual, err = FromProto(&p)
+	assert.Nil(t, err)
+	assert.Equal(t, "PT30S", actual.String())
+
+	_, err = FromProto(nil)
+	assert.ErrorIs(t, err, iso8601duration.ErrBadFormat)
+	_, err = FromProto(&durationpb.Duration{Seconds: 1, Nanos: -1})
+	assert.ErrorIs(t, err, iso8601duration.ErrMixedSign)
+}
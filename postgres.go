@@ -0,0 +1,562 @@
+package iso8601duration
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PostgresIntervalStyle は PostgreSQL の IntervalStyle 設定を表す
+type PostgresIntervalStyle int
+
+const (
+	// IntervalStylePostgres postgres (ex. 1 year 2 mons 3 days 04:05:06.789)
+	IntervalStylePostgres PostgresIntervalStyle = iota
+	// IntervalStylePostgresVerbose postgres_verbose (ex. @ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs)
+	IntervalStylePostgresVerbose
+	// IntervalStyleSQLStandard sql_standard (ex. +1-2 +3 +4:05:06.789)
+	IntervalStyleSQLStandard
+	// IntervalStyleISO8601 iso_8601 (ex. P1Y2M3DT4H5M6.789S)
+	IntervalStyleISO8601
+)
+
+// PostgresInterval は PostgreSQL の interval 型の内部表現 (バイナリプロトコルの値)
+// 各フィールドは個別に符号を持つ
+type PostgresInterval struct {
+	Microseconds int64
+	Days         int32
+	Months       int32
+}
+
+// postgresIntervalSize バイナリ表現のバイト数
+const postgresIntervalSize = 16
+
+var nanosecondsPerMicrosecond = decimal.NewFromInt(int64(time.Microsecond))
+
+// 型チェック
+var (
+	_ encoding.BinaryMarshaler   = PostgresInterval{}
+	_ encoding.BinaryUnmarshaler = (*PostgresInterval)(nil)
+)
+
+// signedDuration はパース途中の、要素ごとに符号を持つ期間
+type signedDuration struct {
+	years       int64
+	months      int64
+	weeks       int64
+	days        int64
+	hours       int64
+	minutes     int64
+	seconds     int64
+	nanoseconds int64
+}
+
+// negate は全ての要素の符号を反転する
+func (s *signedDuration) negate() {
+	s.years, s.months, s.weeks, s.days = -s.years, -s.months, -s.weeks, -s.days
+	s.hours, s.minutes, s.seconds, s.nanoseconds = -s.hours, -s.minutes, -s.seconds, -s.nanoseconds
+}
+
+// toDuration は Duration に変換する
+// 要素ごとに符号が異なる場合、 ErrMixedSign を返す
+func (s signedDuration) toDuration() (*Duration, error) {
+	values := [...]int64{s.years, s.months, s.weeks, s.days, s.hours, s.minutes, s.seconds, s.nanoseconds}
+	var negative, positive bool
+	for i, v := range values {
+		if v < 0 {
+			negative = true
+			values[i] = -v
+		} else if v > 0 {
+			positive = true
+		}
+		if values[i] > math.MaxUint32 {
+			return nil, ErrOverflow
+		}
+	}
+	if negative && positive {
+		return nil, ErrMixedSign
+	}
+
+	d := Duration{
+		Negative:    negative,
+		Years:       uint32(values[0]),
+		Months:      uint32(values[1]),
+		Weeks:       uint32(values[2]),
+		Days:        uint32(values[3]),
+		Hours:       uint32(values[4]),
+		Minutes:     uint32(values[5]),
+		Seconds:     uint32(values[6]),
+		Nanoseconds: uint32(values[7]),
+	}
+	// ナノ秒のうち、秒単位の桁は、秒に加算する
+	if ok := normalize(&d.Seconds, &d.Nanoseconds, uint32(time.Second)); !ok {
+		return nil, ErrOverflow
+	}
+	return &d, nil
+}
+
+// splitSign は先頭の符号を取り除き、マイナスかを返す
+func splitSign(s string) (string, bool) {
+	if strings.HasPrefix(s, "-") {
+		return s[1:], true
+	}
+	return strings.TrimPrefix(s, "+"), false
+}
+
+// parseUint は符号なしの整数をパースする
+func parseUint(s string) (int64, error) {
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return 0, ErrBadFormat
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, ErrBadFormat
+	}
+	return v, nil
+}
+
+// parseInt は符号付きの整数をパースする
+func parseInt(s string) (int64, error) {
+	s, negative := splitSign(s)
+	v, err := parseUint(s)
+	if negative {
+		return -v, err
+	}
+	return v, err
+}
+
+// parseSeconds は小数を含む秒をパースし、秒とナノ秒を返す (ナノ秒未満は切り捨てる)
+func parseSeconds(s string) (int64, int64, error) {
+	s, negative := splitSign(s)
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	seconds, err := parseUint(whole)
+	if err != nil {
+		return 0, 0, err
+	}
+	var nanoseconds int64
+	if hasFrac {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		nanoseconds, err = parseUint(frac + strings.Repeat("0", 9-len(frac)))
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if negative {
+		return -seconds, -nanoseconds, nil
+	}
+	return seconds, nanoseconds, nil
+}
+
+// parseClock は符号付きの時刻 ([+-]h:mm[:ss[.fffffffff]]) をパースする
+func (s *signedDuration) parseClock(v string) error {
+	v, negative := splitSign(v)
+	parts := strings.Split(v, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ErrBadFormat
+	}
+	var err error
+	if s.hours, err = parseUint(parts[0]); err != nil {
+		return err
+	}
+	if s.minutes, err = parseUint(parts[1]); err != nil {
+		return err
+	}
+	if len(parts) == 3 {
+		if strings.HasPrefix(parts[2], "+") || strings.HasPrefix(parts[2], "-") {
+			return ErrBadFormat
+		}
+		if s.seconds, s.nanoseconds, err = parseSeconds(parts[2]); err != nil {
+			return err
+		}
+	}
+	if negative {
+		s.hours, s.minutes, s.seconds, s.nanoseconds = -s.hours, -s.minutes, -s.seconds, -s.nanoseconds
+	}
+	return nil
+}
+
+// ParsePostgresInterval は PostgreSQL の interval 型の出力を、指定した IntervalStyle の書式としてパースし、 Duration を返す
+// 要素ごとに符号が異なる場合 (ex. 1 mon -1 days) は表現出来ないため、 ErrMixedSign を返す
+func ParsePostgresInterval(s string, style PostgresIntervalStyle) (*Duration, error) {
+	var sd signedDuration
+	var err error
+	switch style {
+	case IntervalStylePostgres:
+		err = sd.parsePostgres(s)
+	case IntervalStylePostgresVerbose:
+		err = sd.parsePostgresVerbose(s)
+	case IntervalStyleSQLStandard:
+		err = sd.parseSQLStandard(s)
+	case IntervalStyleISO8601:
+		err = sd.parsePostgresISO8601(s)
+	default:
+		err = ErrBadFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sd.toDuration()
+}
+
+// parsePostgres は postgres 形式 (ex. -1 years -2 mons +3 days -04:05:06.789) をパースする
+func (s *signedDuration) parsePostgres(v string) error {
+	fields := strings.Fields(v)
+	if len(fields) == 0 {
+		return ErrBadFormat
+	}
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			// 時刻は末尾のみ
+			if i != len(fields)-1 {
+				return ErrBadFormat
+			}
+			return s.parseClock(fields[i])
+		}
+		if i+1 >= len(fields) {
+			return ErrBadFormat
+		}
+		n, err := parseInt(fields[i])
+		if err != nil {
+			return err
+		}
+		i++
+		switch fields[i] {
+		case "year", "years":
+			s.years = n
+		case "mon", "mons":
+			s.months = n
+		case "day", "days":
+			s.days = n
+		default:
+			return ErrBadFormat
+		}
+	}
+	return nil
+}
+
+// parsePostgresVerbose は postgres_verbose 形式 (ex. @ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago) をパースする
+func (s *signedDuration) parsePostgresVerbose(v string) error {
+	fields := strings.Fields(v)
+	if len(fields) < 2 || fields[0] != "@" {
+		return ErrBadFormat
+	}
+	fields = fields[1:]
+	ago := fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 1 && fields[0] == "0" {
+		return nil
+	}
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return ErrBadFormat
+	}
+	for i := 0; i < len(fields); i += 2 {
+		var err error
+		switch fields[i+1] {
+		case "year", "years":
+			s.years, err = parseInt(fields[i])
+		case "mon", "mons":
+			s.months, err = parseInt(fields[i])
+		case "day", "days":
+			s.days, err = parseInt(fields[i])
+		case "hour", "hours":
+			s.hours, err = parseInt(fields[i])
+		case "min", "mins":
+			s.minutes, err = parseInt(fields[i])
+		case "sec", "secs":
+			s.seconds, s.nanoseconds, err = parseSeconds(fields[i])
+		default:
+			err = ErrBadFormat
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if ago {
+		s.negate()
+	}
+	return nil
+}
+
+// parseSQLStandard は sql_standard 形式 (ex. 1-2 / -3 4:05:06 / +1-2 -3 +4:05:06) をパースする
+// 全ての要素に符号がある場合は要素ごとの符号、そうでない場合は先頭の符号を全体に適用する
+func (s *signedDuration) parseSQLStandard(v string) error {
+	fields := strings.Fields(v)
+	if len(fields) == 0 || len(fields) > 3 {
+		return ErrBadFormat
+	}
+	if len(fields) == 1 && fields[0] == "0" {
+		return nil
+	}
+
+	signed := len(fields) > 1
+	for _, f := range fields {
+		signed = signed && (f[0] == '+' || f[0] == '-')
+	}
+	var negative bool
+	if !signed {
+		fields[0], negative = splitSign(fields[0])
+	}
+
+	for i, f := range fields {
+		f, fieldNegative := splitSign(f)
+		var part signedDuration
+		switch {
+		case strings.Contains(f, ":"):
+			// 時刻は末尾のみ
+			if i != len(fields)-1 {
+				return ErrBadFormat
+			}
+			if err := part.parseClock(f); err != nil {
+				return err
+			}
+		case strings.Contains(f, "-"):
+			// 年月は先頭のみ
+			if i != 0 {
+				return ErrBadFormat
+			}
+			years, months, _ := strings.Cut(f, "-")
+			var err error
+			if part.years, err = parseUint(years); err != nil {
+				return err
+			}
+			if part.months, err = parseUint(months); err != nil {
+				return err
+			}
+		default:
+			var err error
+			if part.days, err = parseUint(f); err != nil {
+				return err
+			}
+		}
+		if fieldNegative {
+			part.negate()
+		}
+		s.add(part)
+	}
+	if negative {
+		s.negate()
+	}
+	return nil
+}
+
+// add は各要素を加算する
+func (s *signedDuration) add(o signedDuration) {
+	s.years += o.years
+	s.months += o.months
+	s.weeks += o.weeks
+	s.days += o.days
+	s.hours += o.hours
+	s.minutes += o.minutes
+	s.seconds += o.seconds
+	s.nanoseconds += o.nanoseconds
+}
+
+// parsePostgresISO8601 は iso_8601 形式 (ex. P-1Y-2M3DT-4H-5M-6.789S) をパースする
+// 要素ごとの符号と、先頭の符号 (ex. -P1Y) の両方を受け付ける
+func (s *signedDuration) parsePostgresISO8601(v string) error {
+	v, negative := splitSign(v)
+	rest, ok := strings.CutPrefix(v, "P")
+	if !ok || rest == "" {
+		return ErrBadFormat
+	}
+
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return ErrBadFormat
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		i := strings.IndexAny(rest, "YMWDHS")
+		if i <= 0 {
+			return ErrBadFormat
+		}
+		number, designator := rest[:i], rest[i]
+		rest = rest[i+1:]
+
+		var err error
+		switch {
+		case !inTime && designator == 'Y':
+			s.years, err = parseInt(number)
+		case !inTime && designator == 'M':
+			s.months, err = parseInt(number)
+		case !inTime && designator == 'W':
+			s.weeks, err = parseInt(number)
+		case !inTime && designator == 'D':
+			s.days, err = parseInt(number)
+		case inTime && designator == 'H':
+			s.hours, err = parseInt(number)
+		case inTime && designator == 'M':
+			s.minutes, err = parseInt(number)
+		case inTime && designator == 'S':
+			s.seconds, s.nanoseconds, err = parseSeconds(number)
+		default:
+			err = ErrBadFormat
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if negative {
+		s.negate()
+	}
+	return nil
+}
+
+// FormatPostgresInterval は PostgreSQL が interval 型の入力として受け付ける文字列 (postgres 形式) を返す
+// (ex. 1 year 2 mons 3 days 04:05:06.789)
+// 週は日に換算する
+// PostgreSQL の精度はマイクロ秒のため、マイクロ秒未満の値を持つ場合は ErrPrecisionLoss を返す (ToPostgresInterval と同様)
+func FormatPostgresInterval(d Duration) (string, error) {
+	if d.Nanoseconds%1000 != 0 {
+		return "", ErrPrecisionLoss
+	}
+	if d.IsZero() {
+		return "00:00:00", nil
+	}
+
+	var builder strings.Builder
+	writeUnit := func(v uint64, singular, plural string) {
+		if builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+		if d.Negative {
+			builder.WriteByte('-')
+		}
+		builder.WriteString(strconv.FormatUint(v, 10))
+		builder.WriteByte(' ')
+		// PostgreSQL と同様、符号付きで1以外は複数形とする
+		if v == 1 && !d.Negative {
+			builder.WriteString(singular)
+		} else {
+			builder.WriteString(plural)
+		}
+	}
+
+	if d.Years != 0 {
+		writeUnit(uint64(d.Years), "year", "years")
+	}
+	if d.Months != 0 {
+		writeUnit(uint64(d.Months), "mon", "mons")
+	}
+	if days := uint64(d.Weeks)*7 + uint64(d.Days); days != 0 {
+		writeUnit(days, "day", "days")
+	}
+	if d.HasTimePart() {
+		if builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+		if d.Negative {
+			builder.WriteByte('-')
+		}
+		seconds := uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second)
+		minutes := uint64(d.Minutes) + seconds/60
+		hours := uint64(d.Hours) + minutes/60
+		writeTwoDigits(&builder, hours)
+		builder.WriteByte(':')
+		writeTwoDigits(&builder, minutes%60)
+		builder.WriteByte(':')
+		writeTwoDigits(&builder, seconds%60)
+		if nanoseconds := uint64(d.Nanoseconds) % uint64(time.Second); nanoseconds != 0 {
+			builder.WriteByte('.')
+			nanoStr := strconv.FormatUint(nanoseconds, 10)
+			builder.WriteString(strings.Repeat("0", 9-len(nanoStr)))
+			builder.WriteString(strings.TrimRight(nanoStr, "0"))
+		}
+	}
+	return builder.String(), nil
+}
+
+// writeTwoDigits は2桁以上でゼロ埋めした数値を書き込む
+func writeTwoDigits(builder *strings.Builder, v uint64) {
+	if v < 10 {
+		builder.WriteByte('0')
+	}
+	builder.WriteString(strconv.FormatUint(v, 10))
+}
+
+// ToPostgresInterval は PostgreSQL の interval 型の内部表現に変換する
+// 週は日に換算する
+// マイクロ秒未満の値を持つ場合は ErrPrecisionLoss 、範囲を超える場合は ErrOverflow を返す
+func (d Duration) ToPostgresInterval() (PostgresInterval, error) {
+	if d.Nanoseconds%1000 != 0 {
+		return PostgresInterval{}, ErrPrecisionLoss
+	}
+	months := uint64(d.Years)*12 + uint64(d.Months)
+	days := uint64(d.Weeks)*7 + uint64(d.Days)
+	micros := d.timePartNanoseconds().Div(nanosecondsPerMicrosecond)
+	if months > math.MaxInt32 || days > math.MaxInt32 || micros.GreaterThan(maxTimeDuration) {
+		return PostgresInterval{}, ErrOverflow
+	}
+
+	iv := PostgresInterval{
+		Microseconds: micros.IntPart(),
+		Days:         int32(days),
+		Months:       int32(months),
+	}
+	if d.Negative {
+		iv.Microseconds, iv.Days, iv.Months = -iv.Microseconds, -iv.Days, -iv.Months
+	}
+	return iv, nil
+}
+
+// FromPostgresInterval は PostgreSQL の interval 型の内部表現から Duration を返す
+// 月は年と月に、マイクロ秒は時分秒に分割する
+// 要素ごとに符号が異なる場合、 ErrMixedSign を返す
+func FromPostgresInterval(iv PostgresInterval) (Duration, error) {
+	negative := iv.Microseconds < 0 || iv.Days < 0 || iv.Months < 0
+	positive := iv.Microseconds > 0 || iv.Days > 0 || iv.Months > 0
+	if negative && positive {
+		return Duration{}, ErrMixedSign
+	}
+
+	// 最小値は符号反転出来ないため、符号なしで絶対値を求める
+	micros, days, months := uint64(iv.Microseconds), uint32(iv.Days), uint32(iv.Months)
+	if negative {
+		micros, days, months = -micros, -days, -months
+	}
+	seconds := micros / 1000000
+	minutes := seconds / 60
+	return Duration{
+		Negative:    negative,
+		Years:       months / 12,
+		Months:      months % 12,
+		Days:        days,
+		Hours:       uint32(minutes / 60),
+		Minutes:     uint32(minutes % 60),
+		Seconds:     uint32(seconds % 60),
+		Nanoseconds: uint32(micros%1000000) * 1000,
+	}, nil
+}
+
+// MarshalBinary は PostgreSQL のバイナリプロトコルの表現 (int64 マイクロ秒, int32 日, int32 月 のビッグエンディアン) を返す
+func (iv PostgresInterval) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, postgresIntervalSize)
+	b = binary.BigEndian.AppendUint64(b, uint64(iv.Microseconds))
+	b = binary.BigEndian.AppendUint32(b, uint32(iv.Days))
+	b = binary.BigEndian.AppendUint32(b, uint32(iv.Months))
+	return b, nil
+}
+
+// UnmarshalBinary は PostgreSQL のバイナリプロトコルの表現をパースする
+func (iv *PostgresInterval) UnmarshalBinary(data []byte) error {
+	if len(data) != postgresIntervalSize {
+		return ErrBadFormat
+	}
+	iv.Microseconds = int64(binary.BigEndian.Uint64(data[0:8]))
+	iv.Days = int32(binary.BigEndian.Uint32(data[8:12]))
+	iv.Months = int32(binary.BigEndian.Uint32(data[12:16]))
+	return nil
+}
@@ -0,0 +1,220 @@
+package iso8601duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestParsePostgresInterval(t *testing.T) {
+	tests := []struct {
+		style PostgresIntervalStyle
+		s     string
+		want  string
+	}{
+		// postgres
+		{style: IntervalStylePostgres, s: "1 year 2 mons 3 days 04:05:06.789", want: "P1Y2M3DT4H5M6.789S"},
+		{style: IntervalStylePostgres, s: "-1 years -2 mons -3 days -04:05:06", want: "-P1Y2M3DT4H5M6S"},
+		{style: IntervalStylePostgres, s: "2 years", want: "P2Y"},
+		{style: IntervalStylePostgres, s: "1 mon", want: "P1M"},
+		{style: IntervalStylePostgres, s: "-1 days", want: "-P1D"},
+		{style: IntervalStylePostgres, s: "100:00:00", want: "PT100H"},
+		{style: IntervalStylePostgres, s: "-00:00:00.000001", want: "-PT0.000001S"},
+		{style: IntervalStylePostgres, s: "00:00:00", want: "PT0S"},
+		// postgres_verbose
+		{style: IntervalStylePostgresVerbose, s: "@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs", want: "P1Y2M3DT4H5M6.789S"},
+		{style: IntervalStylePostgresVerbose, s: "@ 1 year 2 mons 3 days 4 hours 5 mins 6 secs ago", want: "-P1Y2M3DT4H5M6S"},
+		{style: IntervalStylePostgresVerbose, s: "@ 1 min", want: "PT1M"},
+		{style: IntervalStylePostgresVerbose, s: "@ 0.5 secs ago", want: "-PT0.5S"},
+		{style: IntervalStylePostgresVerbose, s: "@ 0", want: "PT0S"},
+		// sql_standard
+		{style: IntervalStyleSQLStandard, s: "1-2", want: "P1Y2M"},
+		{style: IntervalStyleSQLStandard, s: "-1-2", want: "-P1Y2M"},
+		{style: IntervalStyleSQLStandard, s: "3 4:05:06.789", want: "P3DT4H5M6.789S"},
+		{style: IntervalStyleSQLStandard, s: "-3 4:05:06", want: "-P3DT4H5M6S"},
+		{style: IntervalStyleSQLStandard, s: "4:05:06", want: "PT4H5M6S"},
+		{style: IntervalStyleSQLStandard, s: "-0:00:01", want: "-PT1S"},
+		{style: IntervalStyleSQLStandard, s: "+1-2 +3 +4:05:06.789", want: "P1Y2M3DT4H5M6.789S"},
+		{style: IntervalStyleSQLStandard, s: "-1-2 -3 -4:05:06", want: "-P1Y2M3DT4H5M6S"},
+		{style: IntervalStyleSQLStandard, s: "0", want: "PT0S"},
+		// iso_8601
+		{style: IntervalStyleISO8601, s: "P1Y2M3DT4H5M6.789S", want: "P1Y2M3DT4H5M6.789S"},
+		{style: IntervalStyleISO8601, s: "P-1Y-2M-3DT-4H-5M-6.789S", want: "-P1Y2M3DT4H5M6.789S"},
+		{style: IntervalStyleISO8601, s: "-P1W", want: "-P1W"},
+		{style: IntervalStyleISO8601, s: "PT0S", want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			actual, err := ParsePostgresInterval(tt.s, tt.style)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual.String())
+		})
+	}
+
+	// 要素ごとに符号が異なる
+	mixed := []struct {
+		style PostgresIntervalStyle
+		s     string
+	}{
+		{style: IntervalStylePostgres, s: "1 mon -1 days"},
+		{style: IntervalStylePostgres, s: "-1 years -2 mons +3 days -04:05:06"},
+		{style: IntervalStylePostgresVerbose, s: "@ 1 year 2 mons -3 days 4 hours 5 mins 6 secs ago"},
+		{style: IntervalStyleSQLStandard, s: "-1-2 +3 -4:05:06"},
+		{style: IntervalStyleISO8601, s: "P-1Y-2M3DT-4H-5M-6S"},
+	}
+	for _, tt := range mixed {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := ParsePostgresInterval(tt.s, tt.style)
+			assert.ErrorIs(t, err, ErrMixedSign)
+		})
+	}
+
+	// フォーマット不正
+	invalid := []struct {
+		style PostgresIntervalStyle
+		s     string
+	}{
+		{style: IntervalStylePostgres, s: ""},
+		{style: IntervalStylePostgres, s: "1 week"},
+		{style: IntervalStylePostgres, s: "04:05:06 1 day"},
+		{style: IntervalStylePostgres, s: "1"},
+		{style: IntervalStylePostgresVerbose, s: "1 year"},
+		{style: IntervalStylePostgresVerbose, s: "@ 1"},
+		{style: IntervalStyleSQLStandard, s: "1-2-3"},
+		{style: IntervalStyleSQLStandard, s: "3 1-2"},
+		{style: IntervalStyleSQLStandard, s: "4:05:-06"},
+		{style: IntervalStyleISO8601, s: "P"},
+		{style: IntervalStyleISO8601, s: "P1H"},
+		{style: IntervalStyleISO8601, s: "PT1D"},
+		{style: IntervalStyleISO8601, s: "P1.5Y"},
+		{style: PostgresIntervalStyle(-1), s: "P1Y"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := ParsePostgresInterval(tt.s, tt.style)
+			assert.ErrorIs(t, err, ErrBadFormat)
+		})
+	}
+}
+
+func TestFormatPostgresInterval(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     string
+	}{
+		{duration: "P1Y2M3DT4H5M6.789S", want: "1 year 2 mons 3 days 04:05:06.789"},
+		{duration: "-P1Y2M3DT4H5M6S", want: "-1 years -2 mons -3 days -04:05:06"},
+		{duration: "P2Y1M", want: "2 years 1 mon"},
+		{duration: "P1W1D", want: "8 days"},
+		{duration: "PT100H", want: "100:00:00"},
+		{duration: "PT90M", want: "01:30:00"},
+		{duration: "PT0.000001S", want: "00:00:00.000001"},
+		{duration: "PT0S", want: "00:00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+			actual, err := FormatPostgresInterval(*sut)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	// マイクロ秒未満
+	_, err := FormatPostgresInterval(Duration{Negative: true, Hours: 25, Nanoseconds: 1500})
+	assert.ErrorIs(t, err, ErrPrecisionLoss)
+
+	// プロパティテスト (postgres 形式でパース出来る)
+	rapid.Check(t, func(t *rapid.T) {
+		sut := Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32().Draw(t, "years"),
+			Months:      rapid.Uint32Max(11).Draw(t, "months"),
+			Days:        rapid.Uint32().Draw(t, "days"),
+			Hours:       rapid.Uint32().Draw(t, "hours"),
+			Minutes:     rapid.Uint32Max(59).Draw(t, "minutes"),
+			Seconds:     rapid.Uint32Max(59).Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32Max(999999).Draw(t, "microseconds") * 1000,
+		}
+		if sut.IsZero() {
+			sut.Negative = false
+		}
+
+		s, err := FormatPostgresInterval(sut)
+		assert.Nil(t, err)
+		actual, err := ParsePostgresInterval(s, IntervalStylePostgres)
+		assert.Nil(t, err)
+		assert.Equal(t, sut, *actual)
+	})
+}
+
+func TestPostgresInterval(t *testing.T) {
+	sut := Duration{Years: 1, Months: 2, Weeks: 1, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 789000000}
+	iv, err := sut.ToPostgresInterval()
+	assert.Nil(t, err)
+	assert.Equal(t, PostgresInterval{Microseconds: 14706789000, Days: 10, Months: 14}, iv)
+
+	actual, err := FromPostgresInterval(iv)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Years: 1, Months: 2, Days: 10, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 789000000}, actual)
+
+	// マイナス
+	iv, err = sut.Negate().ToPostgresInterval()
+	assert.Nil(t, err)
+	assert.Equal(t, PostgresInterval{Microseconds: -14706789000, Days: -10, Months: -14}, iv)
+	actual, err = FromPostgresInterval(iv)
+	assert.Nil(t, err)
+	assert.True(t, actual.Negative)
+
+	// 最小値
+	actual, err = FromPostgresInterval(PostgresInterval{Microseconds: math.MinInt64, Days: math.MinInt32, Months: math.MinInt32})
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Negative: true, Years: 178956970, Months: 8, Days: 2147483648, Hours: 2562047788, Minutes: 0, Seconds: 54, Nanoseconds: 775808000}, actual)
+
+	// 要素ごとに符号が異なる
+	_, err = FromPostgresInterval(PostgresInterval{Days: -1, Months: 1})
+	assert.ErrorIs(t, err, ErrMixedSign)
+
+	// 精度
+	_, err = Duration{Nanoseconds: 1}.ToPostgresInterval()
+	assert.ErrorIs(t, err, ErrPrecisionLoss)
+
+	// オーバーフロー
+	_, err = Duration{Years: math.MaxInt32}.ToPostgresInterval()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Duration{Days: math.MaxInt32, Weeks: 1}.ToPostgresInterval()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Duration{Hours: math.MaxUint32}.ToPostgresInterval()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	// バイナリ
+	iv = PostgresInterval{Microseconds: 14706789000, Days: -10, Months: 14}
+	b, err := iv.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 3, 0x6c, 0x97, 0xca, 0x88, 0xff, 0xff, 0xff, 0xf6, 0, 0, 0, 14}, b)
+	var decoded PostgresInterval
+	assert.Nil(t, decoded.UnmarshalBinary(b))
+	assert.Equal(t, iv, decoded)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(b[:15]), ErrBadFormat)
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		iv := PostgresInterval{
+			Microseconds: rapid.Int64Min(0).Draw(t, "microseconds"),
+			Days:         rapid.Int32Min(0).Draw(t, "days"),
+			Months:       rapid.Int32Min(0).Draw(t, "months"),
+		}
+		if rapid.Bool().Draw(t, "negative") {
+			iv.Microseconds, iv.Days, iv.Months = -iv.Microseconds, -iv.Days, -iv.Months
+		}
+
+		d, err := FromPostgresInterval(iv)
+		assert.Nil(t, err)
+		actual, err := d.ToPostgresInterval()
+		assert.Nil(t, err)
+		assert.Equal(t, iv, actual)
+	})
+}
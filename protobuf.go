@@ -0,0 +1,72 @@
+package iso8601duration
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// maxProtoSeconds google.protobuf.Duration の秒の範囲 (約10000年)
+	maxProtoSeconds = 315576000000
+)
+
+var (
+	// ErrCalendarComponent 年月を含む期間は、基準日時なしで秒に換算出来ない
+	ErrCalendarComponent = errors.New("calendar component requires a reference time")
+)
+
+// ToSecondsNanos は google.protobuf.Duration と同じ表現 (符号が共通の秒とナノ秒) に変換する
+// 1日は24時間として換算する
+// 年月を含む場合は ErrCalendarComponent 、範囲 (±315,576,000,000秒) を超える場合は ErrOverflow を返す
+func (d Duration) ToSecondsNanos() (int64, int32, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, 0, ErrCalendarComponent
+	}
+	ns := d.dayTimeNanoseconds()
+	if d.Negative {
+		ns = ns.Neg()
+	}
+	return toSecondsNanos(ns)
+}
+
+// ToSecondsNanosAt は基準日時 ref から暦に従って換算し、 google.protobuf.Duration と同じ表現 (符号が共通の秒とナノ秒) に変換する
+// 範囲 (±315,576,000,000秒) を超える場合は ErrOverflow を返す
+func (d Duration) ToSecondsNanosAt(ref time.Time) (int64, int32, error) {
+	return toSecondsNanos(d.nanosecondsAt(ref))
+}
+
+func toSecondsNanos(ns decimal.Decimal) (int64, int32, error) {
+	seconds, nanos := ns.QuoRem(nanosecondsPerSeconds, 0)
+	if seconds.Abs().GreaterThan(decimal.NewFromInt(maxProtoSeconds)) {
+		return 0, 0, ErrOverflow
+	}
+	return seconds.IntPart(), int32(nanos.IntPart()), nil
+}
+
+// FromSecondsNanos は google.protobuf.Duration と同じ表現 (符号が共通の秒とナノ秒) から、時刻部 (時分秒) のみの Duration を返す
+// 秒とナノ秒の符号が異なる場合は ErrMixedSign 、ナノ秒が ±999,999,999 を超える場合は ErrBadFormat 、
+// 秒が範囲 (±315,576,000,000秒) を超える場合は ErrOverflow を返す
+func FromSecondsNanos(seconds int64, nanos int32) (Duration, error) {
+	if (seconds < 0 && nanos > 0) || (seconds > 0 && nanos < 0) {
+		return Duration{}, ErrMixedSign
+	}
+	if nanos <= -int32(time.Second) || nanos >= int32(time.Second) {
+		return Duration{}, ErrBadFormat
+	}
+	if seconds < -maxProtoSeconds || seconds > maxProtoSeconds {
+		return Duration{}, ErrOverflow
+	}
+
+	var d Duration
+	if seconds < 0 || nanos < 0 {
+		d.Negative = true
+		seconds, nanos = -seconds, -nanos
+	}
+	d.Nanoseconds = uint32(nanos)
+	d.Seconds = uint32(seconds % 60)
+	d.Minutes = uint32(seconds / 60 % 60)
+	d.Hours = uint32(seconds / 3600)
+	return d, nil
+}
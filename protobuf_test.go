@@ -0,0 +1,90 @@
+package iso8601duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestToSecondsNanos(t *testing.T) {
+	tests := []struct {
+		duration string
+		seconds  int64
+		nanos    int32
+	}{
+		{duration: "PT0S", seconds: 0, nanos: 0},
+		{duration: "PT1.5S", seconds: 1, nanos: 500000000},
+		{duration: "-PT1.5S", seconds: -1, nanos: -500000000},
+		{duration: "-PT0.000000001S", seconds: 0, nanos: -1},
+		{duration: "P1W1DT1H", seconds: 8*86400 + 3600, nanos: 0},
+		{duration: "PT87660000H", seconds: 315576000000, nanos: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			seconds, nanos, err := sut.ToSecondsNanos()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.seconds, seconds)
+			assert.Equal(t, tt.nanos, nanos)
+		})
+	}
+
+	// 年月
+	_, _, err := Duration{Months: 1}.ToSecondsNanos()
+	assert.ErrorIs(t, err, ErrCalendarComponent)
+
+	// オーバーフロー
+	_, _, err = Duration{Hours: 87660000, Nanoseconds: uint32(time.Second)}.ToSecondsNanos()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	// 基準日時
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	seconds, nanos, err := Duration{Months: 1, Nanoseconds: 1}.ToSecondsNanosAt(ref)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(28*86400), seconds)
+	assert.Equal(t, int32(1), nanos)
+	seconds, nanos, err = Duration{Negative: true, Months: 1, Nanoseconds: 1}.ToSecondsNanosAt(ref)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-31*86400), seconds)
+	assert.Equal(t, int32(-1), nanos)
+	_, _, err = Duration{Years: 10001}.ToSecondsNanosAt(ref)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestFromSecondsNanos(t *testing.T) {
+	actual, err := FromSecondsNanos(5400, 500000000)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Hours: 1, Minutes: 30, Nanoseconds: 500000000}, actual)
+
+	actual, err = FromSecondsNanos(0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration{Negative: true, Nanoseconds: 1}, actual)
+
+	_, err = FromSecondsNanos(1, -1)
+	assert.ErrorIs(t, err, ErrMixedSign)
+	_, err = FromSecondsNanos(0, int32(time.Second))
+	assert.ErrorIs(t, err, ErrBadFormat)
+	_, err = FromSecondsNanos(math.MinInt64, 0)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		seconds := rapid.Int64Range(-maxProtoSeconds, maxProtoSeconds).Draw(t, "seconds")
+		nanos := rapid.Int32Range(0, int32(time.Second)-1).Draw(t, "nanos")
+		if seconds < 0 {
+			nanos = -nanos
+		}
+
+		d, err := FromSecondsNanos(seconds, nanos)
+		assert.Nil(t, err)
+		actualSeconds, actualNanos, err := d.ToSecondsNanos()
+		assert.Nil(t, err)
+		assert.Equal(t, seconds, actualSeconds)
+		assert.Equal(t, nanos, actualNanos)
+	})
+}
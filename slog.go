@@ -0,0 +1,61 @@
+package iso8601duration
+
+import (
+	"log/slog"
+)
+
+// 型チェック
+var (
+	_ slog.LogValuer = Duration{}
+	_ slog.LogValuer = GroupLogValue{}
+)
+
+// GroupLogValue は slog で、0でない要素ごとの属性を持つグループとして出力する Duration
+type GroupLogValue struct {
+	Duration
+}
+
+// LogValue は slog.LogValuer を実装し、ISO-8601 Duration書式の文字列を返す
+func (d Duration) LogValue() slog.Value {
+	return slog.StringValue(d.String())
+}
+
+// LogValue は slog.LogValuer を実装し、0でない要素ごとの属性を持つグループを返す
+// マイナスの場合は negative=true を含め、ゼロ値の場合は seconds=0 のみとする
+func (d GroupLogValue) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 9)
+	if d.Negative && !d.IsZero() {
+		attrs = append(attrs, slog.Bool("negative", true))
+	}
+	for _, f := range []struct {
+		key   string
+		value uint32
+	}{
+		{"years", d.Years},
+		{"months", d.Months},
+		{"weeks", d.Weeks},
+		{"days", d.Days},
+		{"hours", d.Hours},
+		{"minutes", d.Minutes},
+		{"seconds", d.Seconds},
+		{"nanoseconds", d.Nanoseconds},
+	} {
+		if f.value != 0 {
+			attrs = append(attrs, slog.Uint64(f.key, uint64(f.value)))
+		}
+	}
+	if d.IsZero() {
+		attrs = append(attrs, slog.Uint64("seconds", 0))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Attr はISO-8601 Duration書式の文字列を値とする slog.Attr を返す
+func Attr(key string, d Duration) slog.Attr {
+	return slog.Attr{Key: key, Value: d.LogValue()}
+}
+
+// GroupAttr は0でない要素ごとの属性を持つグループを値とする slog.Attr を返す
+func GroupAttr(key string, d Duration) slog.Attr {
+	return slog.Attr{Key: key, Value: GroupLogValue{d}.LogValue()}
+}
@@ -0,0 +1,45 @@
+package iso8601duration
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	d := Duration{Days: 30, Hours: 1, Nanoseconds: 500000000}
+	logger.Info("value", "retention", d)
+	logger.Info("pointer", "retention", &d)
+	logger.Info("attr", Attr("retention", d))
+	logger.Info("group", GroupAttr("retention", d))
+	logger.Info("group", "retention", GroupLogValue{Duration{Negative: true, Years: 1}})
+	logger.Info("zero", GroupAttr("retention", Duration{Negative: true}))
+
+	assert.Equal(t, "level=INFO msg=value retention=P30DT1H0.5S\n"+
+		"level=INFO msg=pointer retention=P30DT1H0.5S\n"+
+		"level=INFO msg=attr retention=P30DT1H0.5S\n"+
+		"level=INFO msg=group retention.days=30 retention.hours=1 retention.nanoseconds=500000000\n"+
+		"level=INFO msg=group retention.negative=true retention.years=1\n"+
+		"level=INFO msg=zero retention.seconds=0\n", buf.String())
+}
+
+func TestStringValue(t *testing.T) {
+	// 値レシーバのため、値でも fmt.Stringer として扱われる
+	d := Duration{Negative: true, Months: 1}
+	assert.Equal(t, "-P1M", fmt.Sprint(d))
+	assert.Equal(t, "-P1M", fmt.Sprint(&d))
+	assert.Equal(t, "[-P1M PT0S]", fmt.Sprint([]Duration{d, {}}))
+}
@@ -0,0 +1,73 @@
+package iso8601duration
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrUnsupportedScanType Scan 未対応の型
+	ErrUnsupportedScanType = errors.New("unsupported scan type")
+)
+
+// 型チェック
+var (
+	_ sql.Scanner   = (*Duration)(nil)
+	_ driver.Valuer = Duration{}
+	_ sql.Scanner   = (*NullDuration)(nil)
+	_ driver.Valuer = NullDuration{}
+)
+
+// Scan は sql.Scanner を実装する
+// 文字列はISO-8601 Duration書式として、整数はナノ秒としてパースする
+func (d *Duration) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case int64:
+		*d = FromTimeDuration(time.Duration(v))
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedScanType, src)
+	}
+}
+
+// Value は driver.Valuer を実装する
+// ISO-8601 Duration書式の文字列を返す
+func (d Duration) Value() (driver.Value, error) {
+	b, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// NullDuration は NULL を許容する Duration (sql.NullTime と同様)
+type NullDuration struct {
+	Duration Duration
+	// Valid Duration が NULL でない場合 true
+	Valid bool
+}
+
+// Scan は sql.Scanner を実装する
+func (n *NullDuration) Scan(src any) error {
+	if src == nil {
+		n.Duration, n.Valid = Duration{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Duration.Scan(src)
+}
+
+// Value は driver.Valuer を実装する
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Duration.Value()
+}
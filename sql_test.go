@@ -0,0 +1,135 @@
+package iso8601duration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver は Query で rows の値を返し、 Exec で引数を記録するテスト用ドライバ
+type fakeDriver struct {
+	rows [][]driver.Value
+	args []driver.Value
+}
+
+type fakeConn struct{ d *fakeDriver }
+
+type fakeStmt struct{ d *fakeDriver }
+
+type fakeRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{d: c.d}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = args
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+func (r *fakeRows) Columns() []string { return []string{"duration"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+type fakeConnector struct{ d *fakeDriver }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c fakeConnector) Driver() driver.Driver                        { return c.d }
+
+func TestSQLScan(t *testing.T) {
+	fd := &fakeDriver{rows: [][]driver.Value{
+		{"P1Y2M3DT4H5M6.7S"},
+		{[]byte("-PT30S")},
+		{int64(90 * time.Minute)},
+		{nil},
+		{1.5},
+	}}
+	db := sql.OpenDB(fakeConnector{d: fd})
+	defer db.Close()
+
+	// Duration
+	rows, err := db.Query("SELECT duration")
+	assert.Nil(t, err)
+	var actual []Duration
+	var errs []error
+	for rows.Next() {
+		var d Duration
+		if err := rows.Scan(&d); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		actual = append(actual, d)
+	}
+	assert.Nil(t, rows.Close())
+	assert.Equal(t, []Duration{
+		{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 700 * 1000 * 1000},
+		{Negative: true, Seconds: 30},
+		{Hours: 1, Minutes: 30},
+	}, actual)
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrUnsupportedScanType)
+	assert.ErrorIs(t, errs[1], ErrUnsupportedScanType)
+
+	// NullDuration
+	rows, err = db.Query("SELECT duration")
+	assert.Nil(t, err)
+	var nullActual []NullDuration
+	for rows.Next() {
+		var d NullDuration
+		if err := rows.Scan(&d); err != nil {
+			continue
+		}
+		nullActual = append(nullActual, d)
+	}
+	assert.Nil(t, rows.Close())
+	assert.Equal(t, []NullDuration{
+		{Valid: true, Duration: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 700 * 1000 * 1000}},
+		{Valid: true, Duration: Duration{Negative: true, Seconds: 30}},
+		{Valid: true, Duration: Duration{Hours: 1, Minutes: 30}},
+		{Valid: false},
+	}, nullActual)
+
+	// フォーマット不正
+	var d Duration
+	assert.ErrorIs(t, d.Scan("1 day"), ErrBadFormat)
+}
+
+func TestSQLValue(t *testing.T) {
+	fd := &fakeDriver{}
+	db := sql.OpenDB(fakeConnector{d: fd})
+	defer db.Close()
+
+	_, err := db.Exec("INSERT", Duration{Days: 3, Hours: 12})
+	assert.Nil(t, err)
+	assert.Equal(t, []driver.Value{"P3DT12H"}, fd.args)
+
+	_, err = db.Exec("INSERT", NullDuration{Valid: true, Duration: Duration{Negative: true, Months: 1}})
+	assert.Nil(t, err)
+	assert.Equal(t, []driver.Value{"-P1M"}, fd.args)
+
+	_, err = db.Exec("INSERT", NullDuration{})
+	assert.Nil(t, err)
+	assert.Equal(t, []driver.Value{nil}, fd.args)
+}
@@ -0,0 +1,296 @@
+package iso8601duration
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLDialect は INTERVAL リテラルの方言を表す
+type SQLDialect int
+
+const (
+	// SQLDialectStandard SQL標準 (ex. INTERVAL '1-2' YEAR TO MONTH)
+	SQLDialectStandard SQLDialect = iota
+	// SQLDialectMySQL MySQL (ex. INTERVAL '1-2' YEAR_MONTH)
+	SQLDialectMySQL
+)
+
+// sqlIntervalField は INTERVAL の単位を表す
+type sqlIntervalField int
+
+const (
+	sqlFieldYear sqlIntervalField = iota
+	sqlFieldQuarter
+	sqlFieldMonth
+	sqlFieldWeek
+	sqlFieldDay
+	sqlFieldHour
+	sqlFieldMinute
+	sqlFieldSecond
+	sqlFieldMicrosecond
+)
+
+var (
+	// ErrMixedIntervalType 年月と日時を両方持つ期間は、1つの INTERVAL で表現出来ない
+	ErrMixedIntervalType = errors.New("year-month and day-time intervals can't be combined")
+
+	// sqlIntervalPattern INTERVAL [+-] ('値' | 数値) 単位[(精度)] [TO 単位[(精度)]]
+	sqlIntervalPattern = regexp.MustCompile(`(?i)^\s*INTERVAL\s*([+-]?)\s*(?:'([^']*)'|(\d+(?:\.\d+)?))\s+([A-Z_]+)(?:\s*\(\s*\d+\s*(?:,\s*\d+\s*)?\))?(?:\s+TO\s+([A-Z]+)(?:\s*\(\s*\d+\s*\))?)?\s*$`)
+
+	sqlStandardSeparator = regexp.MustCompile(`[-\s:]+`)
+	mySQLSeparator       = regexp.MustCompile(`[^0-9]+`)
+
+	sqlIntervalFields = map[string]sqlIntervalField{
+		"YEAR":        sqlFieldYear,
+		"QUARTER":     sqlFieldQuarter,
+		"MONTH":       sqlFieldMonth,
+		"WEEK":        sqlFieldWeek,
+		"DAY":         sqlFieldDay,
+		"HOUR":        sqlFieldHour,
+		"MINUTE":      sqlFieldMinute,
+		"SECOND":      sqlFieldSecond,
+		"MICROSECOND": sqlFieldMicrosecond,
+	}
+)
+
+// isYearMonth は年月の単位かを返す
+func (f sqlIntervalField) isYearMonth() bool {
+	return f <= sqlFieldMonth
+}
+
+// ParseSQLInterval は SQL標準、または MySQL の INTERVAL リテラルをパースし、 Duration を返す
+// 以下の書式を受け付ける
+//   - INTERVAL '1-2' YEAR TO MONTH
+//   - INTERVAL '3 04:05:06.7' DAY TO SECOND
+//   - INTERVAL '-5' DAY(3)
+//   - INTERVAL 5 DAY (MySQL)
+//   - INTERVAL '1:30' HOUR_MINUTE (MySQL)
+//
+// MySQL の *_MICROSECOND 単位では、MySQL と同様に末尾の値をマイクロ秒の値として扱う ('1.5' SECOND_MICROSECOND は1秒と5マイクロ秒)
+func ParseSQLInterval(s string) (*Duration, error) {
+	groups := sqlIntervalPattern.FindStringSubmatch(s)
+	if groups == nil {
+		return nil, ErrBadFormat
+	}
+	negative := groups[1] == "-"
+	value, quoted := groups[2], groups[3] == ""
+	if !quoted {
+		value = groups[3]
+	}
+	start, end := strings.ToUpper(groups[4]), strings.ToUpper(groups[5])
+
+	var fields []sqlIntervalField
+	mysql := false
+	if first, last, ok := strings.Cut(start, "_"); ok {
+		// MySQL の複合単位 (ex. DAY_SECOND)
+		if end != "" || !quoted {
+			return nil, ErrBadFormat
+		}
+		mysql = true
+		fields = sqlIntervalFieldRange(first, last)
+	} else if end != "" {
+		// SQL標準の範囲指定 (ex. DAY TO SECOND)
+		if !quoted {
+			return nil, ErrBadFormat
+		}
+		fields = sqlIntervalFieldRange(start, end)
+		// SQL標準では秒までのため、マイクロ秒は指定出来ない
+		if len(fields) > 0 && fields[len(fields)-1] == sqlFieldMicrosecond {
+			return nil, ErrBadFormat
+		}
+	} else if f, ok := sqlIntervalFields[start]; ok {
+		fields = []sqlIntervalField{f}
+	}
+	if fields == nil {
+		return nil, ErrBadFormat
+	}
+
+	// 値に含まれる符号
+	value, valueNegative := splitSign(strings.TrimSpace(value))
+	if valueNegative {
+		negative = !negative
+	}
+
+	var tokens []string
+	if mysql {
+		tokens = mySQLSeparator.Split(value, -1)
+		// MySQL は値が足りない場合、上位の単位が省略されたとみなす
+		if len(tokens) > len(fields) {
+			return nil, ErrBadFormat
+		}
+		fields = fields[len(fields)-len(tokens):]
+	} else {
+		tokens = sqlStandardSeparator.Split(value, -1)
+		if len(tokens) != len(fields) {
+			return nil, ErrBadFormat
+		}
+	}
+
+	var sd signedDuration
+	for i, f := range fields {
+		// 秒のみ小数を受け付ける
+		if f == sqlFieldSecond && !mysql {
+			seconds, nanoseconds, err := parseSeconds(tokens[i])
+			if err != nil {
+				return nil, err
+			}
+			sd.seconds, sd.nanoseconds = seconds, nanoseconds
+			continue
+		}
+		v, err := parseUint(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		switch f {
+		case sqlFieldYear:
+			sd.years = v
+		case sqlFieldQuarter:
+			sd.months = v * 3
+		case sqlFieldMonth:
+			sd.months = v
+		case sqlFieldWeek:
+			sd.weeks = v
+		case sqlFieldDay:
+			sd.days = v
+		case sqlFieldHour:
+			sd.hours = v
+		case sqlFieldMinute:
+			sd.minutes = v
+		case sqlFieldSecond:
+			sd.seconds = v
+		case sqlFieldMicrosecond:
+			if mysql && len(tokens[i]) > 6 {
+				return nil, ErrBadFormat
+			}
+			sd.nanoseconds = v * int64(time.Microsecond)
+		}
+	}
+	if negative {
+		sd.negate()
+	}
+	return sd.toDuration()
+}
+
+// sqlIntervalFieldRange は start から end までの単位を返す
+// 年月と日時をまたぐ場合や、週・四半期を含む場合は nil を返す
+func sqlIntervalFieldRange(start, end string) []sqlIntervalField {
+	first, ok := sqlIntervalFields[start]
+	if !ok {
+		return nil
+	}
+	last, ok := sqlIntervalFields[end]
+	if !ok || first >= last || first.isYearMonth() != last.isYearMonth() {
+		return nil
+	}
+	var fields []sqlIntervalField
+	for f := first; f <= last; f++ {
+		switch f {
+		case sqlFieldQuarter, sqlFieldWeek:
+			if f == first || f == last {
+				return nil
+			}
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// SplitSQLInterval は期間を、年月の期間と、週日時分秒の期間に分割する
+// SQL では年月の INTERVAL と日時の INTERVAL は別の型のため、それぞれ FormatSQLInterval で変換する
+func (d Duration) SplitSQLInterval() (yearMonth Duration, dayTime Duration) {
+	yearMonth = Duration{Negative: d.Negative, Years: d.Years, Months: d.Months}
+	dayTime = d
+	dayTime.Years = 0
+	dayTime.Months = 0
+	return yearMonth, dayTime
+}
+
+// FormatSQLInterval は期間を INTERVAL リテラルに変換する
+// 年月のみの場合は YEAR TO MONTH 、それ以外は DAY TO SECOND とし、週は日に換算する
+// 年月と日時を両方持つ場合は ErrMixedIntervalType を返すため、 SplitSQLInterval で分割してから変換する
+// MySQL はマイクロ秒までのため、マイクロ秒未満の値を持つ場合は ErrPrecisionLoss を返す
+func FormatSQLInterval(d Duration, dialect SQLDialect) (string, error) {
+	if (d.Years != 0 || d.Months != 0) && (d.Weeks != 0 || d.Days != 0 || d.HasTimePart()) {
+		return "", ErrMixedIntervalType
+	}
+
+	var builder strings.Builder
+	builder.WriteString("INTERVAL '")
+	if d.Negative && !d.IsZero() {
+		builder.WriteByte('-')
+	}
+
+	if d.Years != 0 || d.Months != 0 {
+		months := uint64(d.Years)*12 + uint64(d.Months)
+		years := strconv.FormatUint(months/12, 10)
+		builder.WriteString(years)
+		builder.WriteByte('-')
+		builder.WriteString(strconv.FormatUint(months%12, 10))
+		if dialect == SQLDialectMySQL {
+			builder.WriteString("' YEAR_MONTH")
+			return builder.String(), nil
+		}
+		builder.WriteString("' YEAR")
+		// 既定の精度 (先頭の単位は2桁) を超える場合、精度を指定する
+		if len(years) > 2 {
+			builder.WriteByte('(')
+			builder.WriteString(strconv.Itoa(len(years)))
+			builder.WriteByte(')')
+		}
+		builder.WriteString(" TO MONTH")
+		return builder.String(), nil
+	}
+
+	if dialect == SQLDialectMySQL && d.Nanoseconds%1000 != 0 {
+		return "", ErrPrecisionLoss
+	}
+
+	// 時分秒を日に繰り上げる
+	seconds := ((uint64(d.Weeks)*7+uint64(d.Days))*24+uint64(d.Hours))*3600 + uint64(d.Minutes)*60 + uint64(d.Seconds) + uint64(d.Nanoseconds)/uint64(time.Second)
+	nanoseconds := uint64(d.Nanoseconds) % uint64(time.Second)
+	days := strconv.FormatUint(seconds/86400, 10)
+	builder.WriteString(days)
+	builder.WriteByte(' ')
+	writeTwoDigits(&builder, seconds/3600%24)
+	builder.WriteByte(':')
+	writeTwoDigits(&builder, seconds/60%60)
+	builder.WriteByte(':')
+	writeTwoDigits(&builder, seconds%60)
+
+	nanoStr := strconv.FormatUint(nanoseconds, 10)
+	nanoStr = strings.Repeat("0", 9-len(nanoStr)) + nanoStr
+	if dialect == SQLDialectMySQL {
+		if nanoseconds == 0 {
+			builder.WriteString("' DAY_SECOND")
+		} else {
+			builder.WriteByte('.')
+			builder.WriteString(nanoStr[:6])
+			builder.WriteString("' DAY_MICROSECOND")
+		}
+		return builder.String(), nil
+	}
+
+	fraction := strings.TrimRight(nanoStr, "0")
+	if fraction != "" {
+		builder.WriteByte('.')
+		builder.WriteString(fraction)
+	}
+	builder.WriteString("' DAY")
+	// 既定の精度 (先頭の単位は2桁、秒の小数は6桁) を超える場合、精度を指定する
+	if len(days) > 2 {
+		builder.WriteByte('(')
+		builder.WriteString(strconv.Itoa(len(days)))
+		builder.WriteByte(')')
+	}
+	builder.WriteString(" TO SECOND")
+	if len(fraction) > 6 {
+		builder.WriteByte('(')
+		builder.WriteString(strconv.Itoa(len(fraction)))
+		builder.WriteByte(')')
+	}
+	return builder.String(), nil
+}
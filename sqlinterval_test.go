@@ -0,0 +1,155 @@
+package iso8601duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestParseSQLInterval(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		// SQL標準
+		{s: "INTERVAL '1-2' YEAR TO MONTH", want: "P1Y2M"},
+		{s: "INTERVAL '-1-2' YEAR TO MONTH", want: "-P1Y2M"},
+		{s: "INTERVAL -'1-2' YEAR TO MONTH", want: "-P1Y2M"},
+		{s: "INTERVAL '150-0' YEAR(3) TO MONTH", want: "P150Y"},
+		{s: "INTERVAL '3 04:05:06.7' DAY TO SECOND", want: "P3DT4H5M6.7S"},
+		{s: "interval '3 04:05:06.7' day(3) to second(1)", want: "P3DT4H5M6.7S"},
+		{s: "INTERVAL '3 04' DAY TO HOUR", want: "P3DT4H"},
+		{s: "INTERVAL '3 04:05' DAY TO MINUTE", want: "P3DT4H5M"},
+		{s: "INTERVAL '4:05' HOUR TO MINUTE", want: "PT4H5M"},
+		{s: "INTERVAL '4:05:06' HOUR TO SECOND", want: "PT4H5M6S"},
+		{s: "INTERVAL '5:06.5' MINUTE TO SECOND", want: "PT5M6.5S"},
+		{s: "INTERVAL '5' DAY", want: "P5D"},
+		{s: "INTERVAL '-5' DAY(3)", want: "-P5D"},
+		{s: "INTERVAL '1.5' SECOND", want: "PT1.5S"},
+		{s: "INTERVAL '1.5' SECOND(2,1)", want: "PT1.5S"},
+		// MySQL
+		{s: "INTERVAL 5 DAY", want: "P5D"},
+		{s: "INTERVAL -5 DAY", want: "-P5D"},
+		{s: "INTERVAL 2 WEEK", want: "P2W"},
+		{s: "INTERVAL 1 QUARTER", want: "P3M"},
+		{s: "INTERVAL 1.5 SECOND", want: "PT1.5S"},
+		{s: "INTERVAL 1500 MICROSECOND", want: "PT0.0015S"},
+		{s: "INTERVAL '1-2' YEAR_MONTH", want: "P1Y2M"},
+		{s: "INTERVAL '3 4' DAY_HOUR", want: "P3DT4H"},
+		{s: "INTERVAL '3 4:05:06' DAY_SECOND", want: "P3DT4H5M6S"},
+		{s: "INTERVAL '3 4:05:06.700000' DAY_MICROSECOND", want: "P3DT4H5M6.7S"},
+		{s: "INTERVAL '1:30' HOUR_MINUTE", want: "PT1H30M"},
+		{s: "INTERVAL '-1:30' HOUR_MINUTE", want: "-PT1H30M"},
+		{s: "INTERVAL '1.5' SECOND_MICROSECOND", want: "PT1.000005S"},
+		// MySQL は上位の単位を省略出来る
+		{s: "INTERVAL '1:10' DAY_SECOND", want: "PT1M10S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			actual, err := ParseSQLInterval(tt.s)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual.String())
+		})
+	}
+
+	// フォーマット不正
+	invalid := []string{
+		"'1-2' YEAR TO MONTH",
+		"INTERVAL '1-2' MONTH TO YEAR",
+		"INTERVAL '1-2' YEAR TO DAY",
+		"INTERVAL '1 2' MONTH TO DAY",
+		"INTERVAL '1-2' YEAR_DAY",
+		"INTERVAL '1 2' WEEK TO DAY",
+		"INTERVAL '1' SECOND TO MICROSECOND",
+		"INTERVAL '3 04:05' DAY TO SECOND",
+		"INTERVAL '1:2:3:4:5' DAY_SECOND",
+		"INTERVAL 1 DAY_SECOND",
+		"INTERVAL 1 DAY TO SECOND",
+		"INTERVAL 1.5 DAY",
+		"INTERVAL '1' FORTNIGHT",
+		"INTERVAL '1.1234567' SECOND_MICROSECOND",
+	}
+	for _, s := range invalid {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseSQLInterval(s)
+			assert.ErrorIs(t, err, ErrBadFormat)
+		})
+	}
+}
+
+func TestSplitSQLInterval(t *testing.T) {
+	sut := Duration{Negative: true, Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8}
+	yearMonth, dayTime := sut.SplitSQLInterval()
+	assert.Equal(t, Duration{Negative: true, Years: 1, Months: 2}, yearMonth)
+	assert.Equal(t, Duration{Negative: true, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8}, dayTime)
+
+	_, err := FormatSQLInterval(sut, SQLDialectStandard)
+	assert.ErrorIs(t, err, ErrMixedIntervalType)
+	_, err = FormatSQLInterval(yearMonth, SQLDialectStandard)
+	assert.Nil(t, err)
+	_, err = FormatSQLInterval(dayTime, SQLDialectStandard)
+	assert.Nil(t, err)
+}
+
+func TestFormatSQLInterval(t *testing.T) {
+	tests := []struct {
+		duration string
+		dialect  SQLDialect
+		want     string
+	}{
+		{duration: "P1Y2M", dialect: SQLDialectStandard, want: "INTERVAL '1-2' YEAR TO MONTH"},
+		{duration: "-P14M", dialect: SQLDialectStandard, want: "INTERVAL '-1-2' YEAR TO MONTH"},
+		{duration: "P150Y", dialect: SQLDialectStandard, want: "INTERVAL '150-0' YEAR(3) TO MONTH"},
+		{duration: "P99Y11M", dialect: SQLDialectStandard, want: "INTERVAL '99-11' YEAR TO MONTH"},
+		{duration: "P150Y", dialect: SQLDialectMySQL, want: "INTERVAL '150-0' YEAR_MONTH"},
+		{duration: "P3DT4H5M6.7S", dialect: SQLDialectStandard, want: "INTERVAL '3 04:05:06.7' DAY TO SECOND"},
+		{duration: "P1WT36H", dialect: SQLDialectStandard, want: "INTERVAL '8 12:00:00' DAY TO SECOND"},
+		{duration: "-PT90M", dialect: SQLDialectStandard, want: "INTERVAL '-0 01:30:00' DAY TO SECOND"},
+		{duration: "P400D", dialect: SQLDialectStandard, want: "INTERVAL '400 00:00:00' DAY(3) TO SECOND"},
+		{duration: "PT0.000000001S", dialect: SQLDialectStandard, want: "INTERVAL '0 00:00:00.000000001' DAY TO SECOND(9)"},
+		{duration: "PT0S", dialect: SQLDialectStandard, want: "INTERVAL '0 00:00:00' DAY TO SECOND"},
+		{duration: "P1Y2M", dialect: SQLDialectMySQL, want: "INTERVAL '1-2' YEAR_MONTH"},
+		{duration: "P3DT4H5M6S", dialect: SQLDialectMySQL, want: "INTERVAL '3 04:05:06' DAY_SECOND"},
+		{duration: "-P3DT4H5M6.7S", dialect: SQLDialectMySQL, want: "INTERVAL '-3 04:05:06.700000' DAY_MICROSECOND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+			actual, err := FormatSQLInterval(*sut, tt.dialect)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	// MySQL はマイクロ秒まで
+	_, err := FormatSQLInterval(Duration{Nanoseconds: 1}, SQLDialectMySQL)
+	assert.ErrorIs(t, err, ErrPrecisionLoss)
+
+	// プロパティテスト (パース出来る)
+	rapid.Check(t, func(t *rapid.T) {
+		sut := Duration{
+			Negative: rapid.Bool().Draw(t, "negative"),
+			Days:     rapid.Uint32Max(100000).Draw(t, "days"),
+			Hours:    rapid.Uint32Max(23).Draw(t, "hours"),
+			Minutes:  rapid.Uint32Max(59).Draw(t, "minutes"),
+			Seconds:  rapid.Uint32Max(59).Draw(t, "seconds"),
+		}
+		dialect := SQLDialect(rapid.IntRange(0, 1).Draw(t, "dialect"))
+		if dialect == SQLDialectMySQL {
+			sut.Nanoseconds = rapid.Uint32Max(999999).Draw(t, "microseconds") * 1000
+		} else {
+			sut.Nanoseconds = rapid.Uint32Max(999999999).Draw(t, "nanoseconds")
+		}
+		if sut.IsZero() {
+			sut.Negative = false
+		}
+
+		s, err := FormatSQLInterval(sut, dialect)
+		assert.Nil(t, err)
+		actual, err := ParseSQLInterval(s)
+		assert.Nil(t, err)
+		assert.Equal(t, sut, *actual)
+	})
+}
@@ -0,0 +1,117 @@
+package iso8601duration
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CalendarAssumptions は年・月を日に換算する際に用いる、1年・1ヶ月あたりの日数
+type CalendarAssumptions struct {
+	// DaysPerYear 1年あたりの日数
+	DaysPerYear decimal.Decimal
+	// DaysPerMonth 1ヶ月あたりの日数
+	DaysPerMonth decimal.Decimal
+}
+
+var (
+	// GregorianAverage グレゴリオ暦の平均 (1年 = 365.2425日, 1ヶ月 = 30.436875日)
+	GregorianAverage = CalendarAssumptions{
+		DaysPerYear:  decimal.RequireFromString("365.2425"),
+		DaysPerMonth: decimal.RequireFromString("30.436875"),
+	}
+
+	// Thirty360 30/360方式 (1年 = 360日, 1ヶ月 = 30日)
+	Thirty360 = CalendarAssumptions{
+		DaysPerYear:  decimal.NewFromInt(360),
+		DaysPerMonth: decimal.NewFromInt(30),
+	}
+
+	daysPerWeek          = decimal.NewFromInt(7)
+	nanosecondsPerMinute = decimal.NewFromInt(int64(time.Minute))
+	nanosecondsPerHour   = decimal.NewFromInt(int64(time.Hour))
+	nanosecondsPerDay    = decimal.NewFromInt(int64(24 * time.Hour))
+
+	minTimeDuration = decimal.NewFromInt(math.MinInt64)
+	maxTimeDuration = decimal.NewFromInt(math.MaxInt64)
+)
+
+// ToTimeDuration は基準日時 ref から期間分経過するまでの time.Duration を返す
+// 年月の長さは基準日時により変わるため、 AddTo(ref).Sub(ref) と同じ値となる
+// time.Duration の範囲 (約±292年) を超える場合、 false を返す
+func (d Duration) ToTimeDuration(ref time.Time) (time.Duration, bool) {
+	// 日付部は暦に従って加算する
+	date := d.addDatePart(ref)
+	dateDuration := date.Sub(ref)
+	if !ref.Add(dateDuration).Equal(date) {
+		// Sub が飽和した (overflow)
+		return 0, false
+	}
+
+	ns := d.timePartNanoseconds()
+	if d.Negative {
+		ns = ns.Neg()
+	}
+	return toTimeDuration(ns.Add(decimal.NewFromInt(int64(dateDuration))))
+}
+
+// ApproxTimeDuration は年・月を assumptions の平均日数で換算した time.Duration を返す
+// 1日は24時間として換算し、ナノ秒未満は切り捨てる
+// time.Duration の範囲 (約±292年) を超える場合、 false を返す
+func (d Duration) ApproxTimeDuration(assumptions CalendarAssumptions) (time.Duration, bool) {
+	ns := d.approxNanoseconds(assumptions)
+	if d.Negative {
+		ns = ns.Neg()
+	}
+	return toTimeDuration(ns)
+}
+
+// approxNanoseconds は年・月を assumptions の平均日数で換算した、符号なしのナノ秒数を返す
+func (d Duration) approxNanoseconds(assumptions CalendarAssumptions) decimal.Decimal {
+	days := assumptions.DaysPerYear.Mul(decimal.NewFromUint64(uint64(d.Years))).
+		Add(assumptions.DaysPerMonth.Mul(decimal.NewFromUint64(uint64(d.Months))))
+	return days.Mul(nanosecondsPerDay).Add(d.dayTimeNanoseconds())
+}
+
+// dayTimeNanoseconds は年月を除いた週日時分秒の、符号なしのナノ秒数を返す (1日 = 24時間)
+func (d Duration) dayTimeNanoseconds() decimal.Decimal {
+	days := daysPerWeek.Mul(decimal.NewFromUint64(uint64(d.Weeks))).Add(decimal.NewFromUint64(uint64(d.Days)))
+	return days.Mul(nanosecondsPerDay).Add(d.timePartNanoseconds())
+}
+
+// timePartNanoseconds は時刻部 (時分秒) の符号なしのナノ秒数を返す
+func (d Duration) timePartNanoseconds() decimal.Decimal {
+	return decimal.NewFromUint64(uint64(d.Hours)).Mul(nanosecondsPerHour).
+		Add(decimal.NewFromUint64(uint64(d.Minutes)).Mul(nanosecondsPerMinute)).
+		Add(decimal.NewFromUint64(uint64(d.Seconds)).Mul(nanosecondsPerSeconds)).
+		Add(decimal.NewFromUint64(uint64(d.Nanoseconds)))
+}
+
+func toTimeDuration(ns decimal.Decimal) (time.Duration, bool) {
+	ns = ns.Truncate(0)
+	if ns.LessThan(minTimeDuration) || ns.GreaterThan(maxTimeDuration) {
+		// overflow
+		return 0, false
+	}
+	return time.Duration(ns.IntPart()), true
+}
+
+// FromTimeDuration は time.Duration から時刻部 (時分秒) のみの Duration を返す
+// 24時間以上は時として保持するため、日に繰り上げる場合は Normalize を使用する
+func FromTimeDuration(td time.Duration) Duration {
+	var d Duration
+	// math.MinInt64 は符号反転出来ないため、符号なしで絶対値を求める
+	abs := uint64(td)
+	if td < 0 {
+		d.Negative = true
+		abs = -abs
+	}
+	d.Nanoseconds = uint32(abs % uint64(time.Second))
+	abs /= uint64(time.Second)
+	d.Seconds = uint32(abs % 60)
+	abs /= 60
+	d.Minutes = uint32(abs % 60)
+	d.Hours = uint32(abs / 60)
+	return d
+}
@@ -0,0 +1,142 @@
+package iso8601duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+)
+
+func TestToTimeDuration(t *testing.T) {
+	tests := []struct {
+		ref      time.Time
+		duration string
+		want     time.Duration
+	}{
+		{ref: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), duration: "P1M", want: 28 * 24 * time.Hour},
+		{ref: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), duration: "P1M", want: 29 * 24 * time.Hour},
+		{ref: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), duration: "-P1M", want: -29 * 24 * time.Hour},
+		{ref: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), duration: "P1Y", want: 366 * 24 * time.Hour},
+		{ref: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), duration: "P1W1DT1H1M1.5S", want: 8*24*time.Hour + time.Hour + time.Minute + 1500*time.Millisecond},
+		{ref: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), duration: "-PT1H", want: -time.Hour},
+		{ref: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), duration: "PT2562047H47M16.854775807S", want: math.MaxInt64},
+		{ref: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), duration: "-PT2562047H47M16.854775808S", want: math.MinInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual, ok := sut.ToTimeDuration(tt.ref)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	// オーバーフロー
+	ref := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, ok := Duration{Years: 293}.ToTimeDuration(ref)
+	assert.False(t, ok)
+	_, ok = Duration{Negative: true, Years: 293}.ToTimeDuration(ref)
+	assert.False(t, ok)
+	_, ok = Duration{Hours: 2562047, Minutes: 47, Seconds: 16, Nanoseconds: 854775808}.ToTimeDuration(ref)
+	assert.False(t, ok)
+	_, ok = Duration{Years: 292, Hours: math.MaxUint32}.ToTimeDuration(ref)
+	assert.False(t, ok)
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		sut := Duration{
+			Negative:    rapid.Bool().Draw(t, "negative"),
+			Years:       rapid.Uint32Max(100).Draw(t, "years"),
+			Months:      rapid.Uint32Max(100).Draw(t, "months"),
+			Weeks:       rapid.Uint32Max(100).Draw(t, "weeks"),
+			Days:        rapid.Uint32Max(100).Draw(t, "days"),
+			Hours:       rapid.Uint32Max(100).Draw(t, "hours"),
+			Minutes:     rapid.Uint32Max(100).Draw(t, "minutes"),
+			Seconds:     rapid.Uint32Max(100).Draw(t, "seconds"),
+			Nanoseconds: rapid.Uint32Max(math.MaxInt32).Draw(t, "nanoseconds"),
+		}
+		ref := time.Unix(rapid.Int64Range(0, 4102444800).Draw(t, "ref"), 0).UTC()
+
+		actual, ok := sut.ToTimeDuration(ref)
+		assert.True(t, ok)
+		assert.Equal(t, sut.AddTo(ref).Sub(ref), actual)
+	})
+}
+
+func TestApproxTimeDuration(t *testing.T) {
+	tests := []struct {
+		duration    string
+		assumptions CalendarAssumptions
+		want        time.Duration
+	}{
+		{duration: "P1Y", assumptions: GregorianAverage, want: 8765*time.Hour + 49*time.Minute + 12*time.Second},
+		{duration: "P1M", assumptions: GregorianAverage, want: 730*time.Hour + 29*time.Minute + 6*time.Second},
+		{duration: "P1Y", assumptions: Thirty360, want: 360 * 24 * time.Hour},
+		{duration: "P1M", assumptions: Thirty360, want: 30 * 24 * time.Hour},
+		{duration: "-P1M", assumptions: Thirty360, want: -30 * 24 * time.Hour},
+		{duration: "P1W1DT1H1M1.5S", assumptions: Thirty360, want: 8*24*time.Hour + time.Hour + time.Minute + 1500*time.Millisecond},
+		{duration: "P292Y", assumptions: GregorianAverage, want: 292 * 31556952 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual, ok := sut.ApproxTimeDuration(tt.assumptions)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	// オーバーフロー
+	_, ok := Duration{Years: 293}.ApproxTimeDuration(GregorianAverage)
+	assert.False(t, ok)
+	_, ok = Duration{Negative: true, Months: 293 * 12}.ApproxTimeDuration(Thirty360)
+	assert.True(t, ok)
+	_, ok = Duration{Negative: true, Months: 300 * 12}.ApproxTimeDuration(GregorianAverage)
+	assert.False(t, ok)
+}
+
+func TestFromTimeDuration(t *testing.T) {
+	tests := []struct {
+		td   time.Duration
+		want string
+	}{
+		{td: 0, want: "PT0S"},
+		{td: 30 * time.Second, want: "PT30S"},
+		{td: 90 * time.Minute, want: "PT1H30M"},
+		{td: 36*time.Hour + 1500*time.Millisecond, want: "PT36H1.5S"},
+		{td: -time.Nanosecond, want: "-PT0.000000001S"},
+		{td: math.MaxInt64, want: "PT2562047H47M16.854775807S"},
+		{td: math.MinInt64, want: "-PT2562047H47M16.854775808S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			actual := FromTimeDuration(tt.td)
+			assert.Equal(t, tt.want, actual.String())
+		})
+	}
+
+	// 日に繰り上げる
+	actual, ok := FromTimeDuration(36 * time.Hour).Normalize()
+	assert.True(t, ok)
+	assert.Equal(t, Duration{Days: 1, Hours: 12}, actual)
+
+	// プロパティテスト
+	rapid.Check(t, func(t *rapid.T) {
+		td := time.Duration(rapid.Int64().Draw(t, "td"))
+
+		sut := FromTimeDuration(td)
+		assert.Less(t, sut.Minutes, uint32(60))
+		assert.Less(t, sut.Seconds, uint32(60))
+		assert.Less(t, sut.Nanoseconds, uint32(time.Second))
+
+		actual, ok := sut.ApproxTimeDuration(GregorianAverage)
+		assert.True(t, ok)
+		assert.Equal(t, td, actual)
+	})
+}
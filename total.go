@@ -0,0 +1,229 @@
+package iso8601duration
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Unit は期間の単位を表す
+type Unit int
+
+const (
+	// UnitNanosecond ナノ秒
+	UnitNanosecond Unit = iota
+	// UnitSecond 秒
+	UnitSecond
+	// UnitMinute 分
+	UnitMinute
+	// UnitHour 時
+	UnitHour
+	// UnitDay 日
+	UnitDay
+	// UnitWeek 週
+	UnitWeek
+	// UnitMonth 月
+	UnitMonth
+	// UnitYear 年
+	UnitYear
+)
+
+var nanosecondsPerWeek = decimal.NewFromInt(int64(7 * 24 * time.Hour))
+
+func (u Unit) String() string {
+	switch u {
+	case UnitNanosecond:
+		return "nanosecond"
+	case UnitSecond:
+		return "second"
+	case UnitMinute:
+		return "minute"
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitYear:
+		return "year"
+	default:
+		return "unknown"
+	}
+}
+
+// isCalendar は年月の単位かを返す
+func (u Unit) isCalendar() bool {
+	return u == UnitMonth || u == UnitYear
+}
+
+// nanoseconds は1単位あたりのナノ秒数を返す (年月は対象外)
+func (u Unit) nanoseconds() decimal.Decimal {
+	switch u {
+	case UnitSecond:
+		return nanosecondsPerSeconds
+	case UnitMinute:
+		return nanosecondsPerMinute
+	case UnitHour:
+		return nanosecondsPerHour
+	case UnitDay:
+		return nanosecondsPerDay
+	case UnitWeek:
+		return nanosecondsPerWeek
+	default:
+		return one
+	}
+}
+
+// months は1単位あたりの月数を返す (年月のみ対象)
+func (u Unit) months() decimal.Decimal {
+	if u == UnitYear {
+		return monthsPerYear
+	}
+	return one
+}
+
+// Total は期間を指定した単位で表した値を返す
+// 年月と週日時分秒は換算出来ないため、単位をまたぐ換算が必要な場合は false を返す
+// (ex. P1M を日で表す場合や、 P1D を月で表す場合)
+// 割り切れない場合、 decimal.DivisionPrecision の桁数に丸められる
+func (d Duration) Total(unit Unit) (decimal.Decimal, bool) {
+	var r decimal.Decimal
+	if unit.isCalendar() {
+		if d.Weeks != 0 || d.Days != 0 || d.HasTimePart() {
+			return decimal.Zero, false
+		}
+		r = d.calendarMonths().Div(unit.months())
+	} else {
+		if d.Years != 0 || d.Months != 0 {
+			return decimal.Zero, false
+		}
+		r = d.dayTimeNanoseconds().Div(unit.nanoseconds())
+	}
+	if d.Negative {
+		return r.Neg(), true
+	}
+	return r, true
+}
+
+// TotalFloat64 は期間を指定した単位で表した値を float64 で返す
+// 換算出来ない場合、 false を返す
+func (d Duration) TotalFloat64(unit Unit) (float64, bool) {
+	r, ok := d.Total(unit)
+	if !ok {
+		return 0, false
+	}
+	f, _ := r.Float64()
+	return f, true
+}
+
+// TotalSeconds は期間を秒で表した値を返す
+func (d Duration) TotalSeconds() (decimal.Decimal, bool) {
+	return d.Total(UnitSecond)
+}
+
+// TotalMinutes は期間を分で表した値を返す
+func (d Duration) TotalMinutes() (decimal.Decimal, bool) {
+	return d.Total(UnitMinute)
+}
+
+// TotalHours は期間を時間で表した値を返す
+func (d Duration) TotalHours() (decimal.Decimal, bool) {
+	return d.Total(UnitHour)
+}
+
+// TotalDays は期間を日で表した値を返す
+func (d Duration) TotalDays() (decimal.Decimal, bool) {
+	return d.Total(UnitDay)
+}
+
+// TotalMonths は期間を月で表した値を返す
+func (d Duration) TotalMonths() (decimal.Decimal, bool) {
+	return d.Total(UnitMonth)
+}
+
+// TotalYears は期間を年で表した値を返す
+func (d Duration) TotalYears() (decimal.Decimal, bool) {
+	return d.Total(UnitYear)
+}
+
+// TotalApprox は年・月を assumptions の平均日数で換算し、期間を指定した単位で表した値を返す
+func (d Duration) TotalApprox(assumptions CalendarAssumptions, unit Unit) decimal.Decimal {
+	ns := d.approxNanoseconds(assumptions)
+	var r decimal.Decimal
+	switch unit {
+	case UnitYear:
+		r = ns.Div(assumptions.DaysPerYear.Mul(nanosecondsPerDay))
+	case UnitMonth:
+		r = ns.Div(assumptions.DaysPerMonth.Mul(nanosecondsPerDay))
+	default:
+		r = ns.Div(unit.nanoseconds())
+	}
+	if d.Negative {
+		return r.Neg()
+	}
+	return r
+}
+
+// TotalAt は基準日時 ref から暦に従って換算し、期間を指定した単位で表した値を返す
+// 月・年で表す場合、週日時分秒は、年月を加算した日時からの1ヶ月の長さに対する割合として換算する
+func (d Duration) TotalAt(ref time.Time, unit Unit) decimal.Decimal {
+	if unit.isCalendar() {
+		return d.monthsAt(ref).Div(unit.months())
+	}
+	return d.nanosecondsAt(ref).Div(unit.nanoseconds())
+}
+
+// calendarMonths は年月を符号なしの月数で返す
+func (d Duration) calendarMonths() decimal.Decimal {
+	return decimal.NewFromUint64(uint64(d.Years)).Mul(monthsPerYear).Add(decimal.NewFromUint64(uint64(d.Months)))
+}
+
+// nanosecondsAt は基準日時 ref から期間分経過するまでの、符号付きのナノ秒数を返す
+// time.Duration の範囲に制限されない
+func (d Duration) nanosecondsAt(ref time.Time) decimal.Decimal {
+	ns := d.timePartNanoseconds()
+	if d.Negative {
+		ns = ns.Neg()
+	}
+	return ns.Add(nanosecondsBetween(ref, d.addDatePart(ref)))
+}
+
+// monthsAt は基準日時 ref から期間分経過するまでの、符号付きの月数を返す
+func (d Duration) monthsAt(ref time.Time) decimal.Decimal {
+	months := d.calendarMonths()
+	if d.Negative {
+		months = months.Neg()
+	}
+
+	// 年月を加算した日時から、残りの期間 (週日時分秒) が何ヶ月分に当たるかを求める
+	base := Duration{Negative: d.Negative, Years: d.Years, Months: d.Months}.addDatePart(ref)
+	rest := d
+	rest.Years = 0
+	rest.Months = 0
+	restNs := rest.nanosecondsAt(base)
+	if restNs.IsZero() {
+		return months
+	}
+
+	// 平均日数から月数を見積もり、 base + n ヶ月 <= 残りの期間 < base + (n+1) ヶ月 となるよう補正する
+	n := int(restNs.Div(GregorianAverage.DaysPerMonth.Mul(nanosecondsPerDay)).Floor().IntPart())
+	for nanosecondsBetween(base, base.AddDate(0, n, 0)).GreaterThan(restNs) {
+		n--
+	}
+	for nanosecondsBetween(base, base.AddDate(0, n+1, 0)).LessThanOrEqual(restNs) {
+		n++
+	}
+	start := nanosecondsBetween(base, base.AddDate(0, n, 0))
+	end := nanosecondsBetween(base, base.AddDate(0, n+1, 0))
+	frac := restNs.Sub(start).Div(end.Sub(start))
+	return months.Add(decimal.NewFromInt(int64(n))).Add(frac)
+}
+
+// nanosecondsBetween は from から to までのナノ秒数を返す
+// time.Time.Sub と異なり、 time.Duration の範囲で飽和しない
+func nanosecondsBetween(from, to time.Time) decimal.Decimal {
+	seconds := decimal.NewFromInt(to.Unix() - from.Unix())
+	return seconds.Mul(nanosecondsPerSeconds).Add(decimal.NewFromInt(int64(to.Nanosecond() - from.Nanosecond())))
+}
@@ -0,0 +1,115 @@
+package iso8601duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTotal(t *testing.T) {
+	tests := []struct {
+		duration string
+		unit     Unit
+		want     string
+		ok       bool
+	}{
+		{duration: "P1DT1H", unit: UnitHour, want: "25", ok: true},
+		{duration: "P1W", unit: UnitSecond, want: "604800", ok: true},
+		{duration: "PT1H30M", unit: UnitHour, want: "1.5", ok: true},
+		{duration: "PT1.5S", unit: UnitNanosecond, want: "1500000000", ok: true},
+		{duration: "-PT36H", unit: UnitDay, want: "-1.5", ok: true},
+		{duration: "P14D", unit: UnitWeek, want: "2", ok: true},
+		{duration: "P1Y6M", unit: UnitYear, want: "1.5", ok: true},
+		{duration: "P1Y6M", unit: UnitMonth, want: "18", ok: true},
+		{duration: "-P2Y", unit: UnitMonth, want: "-24", ok: true},
+		// 単位をまたぐ換算
+		{duration: "P1M", unit: UnitDay, ok: false},
+		{duration: "P1Y", unit: UnitSecond, ok: false},
+		{duration: "P1D", unit: UnitMonth, ok: false},
+		{duration: "PT1S", unit: UnitYear, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration+" "+tt.unit.String(), func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual, ok := sut.Total(tt.unit)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, actual.String())
+			}
+		})
+	}
+
+	sut := Duration{Hours: 1, Minutes: 30}
+	hours, ok := sut.TotalHours()
+	assert.True(t, ok)
+	assert.Equal(t, "1.5", hours.String())
+	minutes, ok := sut.TotalMinutes()
+	assert.True(t, ok)
+	assert.Equal(t, "90", minutes.String())
+	seconds, ok := sut.TotalSeconds()
+	assert.True(t, ok)
+	assert.Equal(t, "5400", seconds.String())
+	days, ok := sut.TotalDays()
+	assert.True(t, ok)
+	assert.Equal(t, "0.0625", days.String())
+	_, ok = sut.TotalMonths()
+	assert.False(t, ok)
+	_, ok = sut.TotalYears()
+	assert.False(t, ok)
+
+	f, ok := sut.TotalFloat64(UnitHour)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, f)
+	_, ok = sut.TotalFloat64(UnitMonth)
+	assert.False(t, ok)
+}
+
+func TestTotalApprox(t *testing.T) {
+	sut := Duration{Years: 1, Months: 6}
+	assert.Equal(t, "540", sut.TotalApprox(Thirty360, UnitDay).String())
+	assert.Equal(t, "547.8637500000000000", sut.TotalApprox(GregorianAverage, UnitDay).StringFixed(16))
+	assert.Equal(t, "18", sut.TotalApprox(GregorianAverage, UnitMonth).String())
+	assert.Equal(t, "1.5", sut.TotalApprox(GregorianAverage, UnitYear).String())
+
+	sut = Duration{Negative: true, Days: 45}
+	assert.Equal(t, "-1.5", sut.TotalApprox(Thirty360, UnitMonth).String())
+	assert.Equal(t, "-0.125", sut.TotalApprox(Thirty360, UnitYear).String())
+}
+
+func TestTotalAt(t *testing.T) {
+	ref := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		duration string
+		unit     Unit
+		want     string
+	}{
+		{duration: "P1M", unit: UnitDay, want: "28"},
+		{duration: "P1Y", unit: UnitDay, want: "365"},
+		{duration: "-P1M", unit: UnitDay, want: "-31"},
+		{duration: "P1MT12H", unit: UnitDay, want: "28.5"},
+		{duration: "P300Y", unit: UnitYear, want: "300"},
+		{duration: "P14D", unit: UnitMonth, want: "0.5"},
+		{duration: "P1M14D", unit: UnitMonth, want: "1.4516129032258065"},
+		{duration: "P42D", unit: UnitMonth, want: "1.4516129032258065"},
+		{duration: "P59D", unit: UnitMonth, want: "2"},
+		{duration: "P1Y6M", unit: UnitYear, want: "1.5"},
+		{duration: "-P31D", unit: UnitMonth, want: "-1"},
+		{duration: "-P1M", unit: UnitMonth, want: "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration+" "+tt.unit.String(), func(t *testing.T) {
+			sut, err := ParseString(tt.duration)
+			assert.Nil(t, err)
+
+			actual := sut.TotalAt(ref, tt.unit)
+			expect, err := decimal.NewFromString(tt.want)
+			assert.Nil(t, err)
+			assert.True(t, expect.Equal(actual), "expected %s, actual %s", expect, actual)
+		})
+	}
+}
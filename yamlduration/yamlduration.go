@@ -0,0 +1,124 @@
+// Package yamlduration は iso8601duration.Duration を YAML (gopkg.in/yaml.v3) で扱うための型を提供する
+package yamlduration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"gopkg.in/yaml.v3"
+)
+
+// Options は YAML のデコード時に受け付ける書式
+type Options struct {
+	// AllowGoDuration Go の time.ParseDuration 書式 (ex. 90s, 1h30m) を受け付ける
+	AllowGoDuration bool
+	// AllowGoDays AllowGoDuration の書式で、日の単位 d を受け付ける (ex. 7d12h)
+	AllowGoDays bool
+	// AllowIntegerSeconds 整数を秒として受け付ける
+	AllowIntegerSeconds bool
+}
+
+// 型チェック
+var (
+	_ yaml.Marshaler   = Duration{}
+	_ yaml.Unmarshaler = (*Duration)(nil)
+	_ yaml.Marshaler   = LenientDuration{}
+	_ yaml.Unmarshaler = (*LenientDuration)(nil)
+)
+
+// Duration は YAML でISO-8601 Duration書式の文字列として扱う Duration
+type Duration struct {
+	iso8601duration.Duration
+}
+
+// LenientDuration は YAML でISO-8601 Duration書式に加えて、 Go の time.ParseDuration 書式 (日の単位 d を含む) と、秒を表す整数を受け付ける Duration
+// 文字列は iso8601duration.LenientDuration と同じ書式を受け付ける
+// 出力はISO-8601 Duration書式とする
+type LenientDuration struct {
+	iso8601duration.Duration
+}
+
+// MarshalYAML は yaml.Marshaler を実装する
+func (d Duration) MarshalYAML() (any, error) {
+	return d.Duration.String(), nil
+}
+
+// UnmarshalYAML は yaml.Unmarshaler を実装する
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return Options{}.decode(node, &d.Duration)
+}
+
+// MarshalYAML は yaml.Marshaler を実装する
+func (d LenientDuration) MarshalYAML() (any, error) {
+	return d.Duration.String(), nil
+}
+
+// UnmarshalYAML は yaml.Unmarshaler を実装する
+func (d *LenientDuration) UnmarshalYAML(node *yaml.Node) error {
+	return Options{AllowGoDuration: true, AllowGoDays: true, AllowIntegerSeconds: true}.decode(node, &d.Duration)
+}
+
+// Decode は YAML のノードをデコードし、 Duration を返す
+// エラーにはノードの行と列を含める
+func (o Options) Decode(node *yaml.Node) (iso8601duration.Duration, error) {
+	var d iso8601duration.Duration
+	err := o.decode(node, &d)
+	return d, err
+}
+
+func (o Options) decode(node *yaml.Node, d *iso8601duration.Duration) error {
+	if node.Kind != yaml.ScalarNode {
+		return nodeError(node, fmt.Errorf("%w: cannot decode %s into duration", iso8601duration.ErrBadFormat, node.ShortTag()))
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!int":
+		if !o.AllowIntegerSeconds {
+			return nodeError(node, fmt.Errorf("%w: integer %q is not allowed", iso8601duration.ErrBadFormat, node.Value))
+		}
+		seconds, err := strconv.ParseInt(node.Value, 0, 64)
+		if err != nil {
+			return nodeError(node, fmt.Errorf("%w: %q", iso8601duration.ErrOverflow, node.Value))
+		}
+		// 時分秒に分割し、時が Duration の範囲 (uint32) を超える場合は ErrOverflow とする
+		// math.MinInt64 は符号反転出来ないため、符号なしで絶対値を求める
+		abs := uint64(seconds)
+		if seconds < 0 {
+			abs = -abs
+		}
+		if abs/3600 > math.MaxUint32 {
+			return nodeError(node, fmt.Errorf("%w: %q", iso8601duration.ErrOverflow, node.Value))
+		}
+		*d = iso8601duration.Duration{
+			Negative: seconds < 0,
+			Hours:    uint32(abs / 3600),
+			Minutes:  uint32(abs / 60 % 60),
+			Seconds:  uint32(abs % 60),
+		}
+		return nil
+	case "!!str":
+		r, err := iso8601duration.ParseString(node.Value)
+		if err == nil {
+			*d = *r
+			return nil
+		}
+		if o.AllowGoDuration {
+			if r, goErr := (iso8601duration.GoParseOptions{AllowDays: o.AllowGoDays}).Parse(node.Value); goErr == nil {
+				*d = *r
+				return nil
+			}
+		}
+		return nodeError(node, fmt.Errorf("%w: %q", err, node.Value))
+	default:
+		return nodeError(node, fmt.Errorf("%w: cannot decode %s %q into duration", iso8601duration.ErrBadFormat, node.ShortTag(), node.Value))
+	}
+}
+
+// nodeError はノードの行と列をエラーに付加する
+func nodeError(node *yaml.Node, err error) error {
+	return fmt.Errorf("yaml: line %d, column %d: %w", node.Line, node.Column, err)
+}
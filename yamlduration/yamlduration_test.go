@@ -0,0 +1,93 @@
+package yamlduration
+
+import (
+	"testing"
+
+	iso8601duration "github.com/gahojin/go-iso8601duration"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Retention Duration        `yaml:"retention"`
+	Timeout   LenientDuration `yaml:"timeout"`
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "retention: P30D\ntimeout: PT30S\n", want: "P30D PT30S"},
+		{src: "retention: -P1Y\ntimeout: 1h30m\n", want: "-P1Y PT1H30M"},
+		{src: "retention: PT0S\ntimeout: 90\n", want: "PT0S PT1M30S"},
+		{src: "retention: ~\ntimeout: -5\n", want: "PT0S -PT5S"},
+		{src: "timeout: \"PT1M\"\n", want: "PT0S PT1M"},
+		{src: "timeout: 7d12h\n", want: "PT0S P7DT12H"},
+		// google.protobuf.Duration の範囲 (約10000年) を超える値
+		{src: "timeout: 315576000001\n", want: "PT0S PT87660000H1S"},
+		{src: "timeout: -15461882265599\n", want: "PT0S -PT4294967295H59M59S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			var actual config
+			assert.Nil(t, yaml.Unmarshal([]byte(tt.src), &actual))
+			assert.Equal(t, tt.want, actual.Retention.Duration.String()+" "+actual.Timeout.Duration.String())
+		})
+	}
+
+	// フォーマット不正
+	invalid := []struct {
+		src  string
+		want string
+	}{
+		{src: "# config\ntimeout: PT30S\n\nretention: 90s\n", want: "yaml: line 4, column 12: bad format string: \"90s\""},
+		{src: "retention: 30\n", want: "yaml: line 1, column 12: bad format string: integer \"30\" is not allowed"},
+		{src: "retention:\n  days: 30\n", want: "yaml: line 2, column 3: bad format string: cannot decode !!map into duration"},
+		{src: "timeout: 1.5\n", want: "yaml: line 1, column 10: bad format string: cannot decode !!float \"1.5\" into duration"},
+		{src: "timeout: 99999999999999999999\n", want: "yaml: line 1, column 10: bad format string: cannot decode !!float \"99999999999999999999\" into duration"},
+		{src: "timeout: 15461882265600\n", want: "yaml: line 1, column 10: duration overflow: \"15461882265600\""},
+		{src: "timeout: -9223372036854775808\n", want: "yaml: line 1, column 10: duration overflow: \"-9223372036854775808\""},
+		{src: "timeout: 1 day\n", want: "yaml: line 1, column 10: bad format string: \"1 day\""},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.src, func(t *testing.T) {
+			var actual config
+			err := yaml.Unmarshal([]byte(tt.src), &actual)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+
+	var c config
+	err := yaml.Unmarshal([]byte("retention: 30\n"), &c)
+	assert.ErrorIs(t, err, iso8601duration.ErrBadFormat)
+}
+
+func TestMarshalYAML(t *testing.T) {
+	actual, err := yaml.Marshal(config{
+		Retention: Duration{iso8601duration.Duration{Days: 30}},
+		Timeout:   LenientDuration{iso8601duration.Duration{Negative: true, Seconds: 30}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "retention: P30D\ntimeout: -PT30S\n", string(actual))
+}
+
+func TestOptionsDecode(t *testing.T) {
+	var node yaml.Node
+	assert.Nil(t, yaml.Unmarshal([]byte("90s"), &node))
+
+	_, err := Options{}.Decode(node.Content[0])
+	assert.ErrorIs(t, err, iso8601duration.ErrBadFormat)
+
+	actual, err := Options{AllowGoDuration: true}.Decode(node.Content[0])
+	assert.Nil(t, err)
+	assert.Equal(t, iso8601duration.Duration{Minutes: 1, Seconds: 30}, actual)
+
+	// 日の単位
+	assert.Nil(t, yaml.Unmarshal([]byte("1d12h"), &node))
+	_, err = Options{AllowGoDuration: true}.Decode(node.Content[0])
+	assert.ErrorIs(t, err, iso8601duration.ErrBadFormat)
+	actual, err = Options{AllowGoDuration: true, AllowGoDays: true}.Decode(node.Content[0])
+	assert.Nil(t, err)
+	assert.Equal(t, iso8601duration.Duration{Days: 1, Hours: 12}, actual)
+}